@@ -0,0 +1,108 @@
+// Package assets provides access to the game resources (fonts, images, sounds).
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+	ttf "github.com/veandco/go-sdl2/ttf"
+)
+
+// Manager loads game assets from the embedded data,
+// unless an asset with the same name exists in the override directory.
+type Manager struct {
+	mu sync.Mutex
+
+	embedded fs.FS
+	override fs.FS
+
+	// loaded assets are kept in memory, since SDL reads them lazily
+	// through RWops and the underlying bytes must outlive the SDL objects
+	cache map[string][]byte
+}
+
+// NewManager creates new instance of Manager, "dir" is an optional override directory,
+// which takes precedence over "embedded" assets, e.g. for modding.
+func NewManager(embedded fs.FS, dir string) *Manager {
+	m := &Manager{
+		embedded: embedded,
+		cache:    make(map[string][]byte),
+	}
+	if dir != "" {
+		m.override = os.DirFS(dir)
+	}
+	return m
+}
+
+// Read returns content of the asset with the given "name", e.g. "fonts/Flappy.ttf".
+func (m *Manager) Read(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if data, ok := m.cache[name]; ok {
+		return data, nil
+	}
+
+	data, err := m.read(name)
+	if err != nil {
+		return nil, err
+	}
+	m.cache[name] = data
+
+	return data, nil
+}
+
+func (m *Manager) read(name string) ([]byte, error) {
+	if m.override != nil {
+		data, err := fs.ReadFile(m.override, name)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("could not read asset %s from override directory: %w", name, err)
+		}
+	}
+
+	data, err := fs.ReadFile(m.embedded, name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read asset %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// RWops returns SDL read stream for the asset with the given "name".
+func (m *Manager) RWops(name string) (*sdl.RWops, error) {
+	data, err := m.Read(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("asset %s is empty", name)
+	}
+
+	rw := sdl.RWFromMem(unsafe.Pointer(&data[0]), len(data))
+	if rw == nil {
+		return nil, fmt.Errorf("could not create stream for asset %s", name)
+	}
+	return rw, nil
+}
+
+// Font opens the font asset with the given "name" and "size", caller is responsible for closing it.
+func (m *Manager) Font(name string, size int) (*ttf.Font, error) {
+	rw, err := m.RWops(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// stream is freed together with the font
+	f, err := ttf.OpenFontRW(rw, 1, size)
+	if err != nil {
+		return nil, fmt.Errorf("could not open font %s: %w", name, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,55 @@
+package assets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEmbedded() fstest.MapFS {
+	return fstest.MapFS{
+		"fonts/font.ttf":  {Data: []byte("embedded font")},
+		"sounds/jump.wav": {Data: []byte("embedded jump")},
+	}
+}
+
+func Test_Read_embedded(t *testing.T) {
+	m := NewManager(newEmbedded(), "")
+
+	data, err := m.Read("fonts/font.ttf")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "embedded font", string(data))
+}
+
+func Test_Read_override(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trovehero-assets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "fonts"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fonts", "font.ttf"), []byte("modded font"), 0644))
+
+	m := NewManager(newEmbedded(), dir)
+
+	font, err := m.Read("fonts/font.ttf")
+	assert.NoError(t, err)
+	assert.Equal(t, "modded font", string(font))
+
+	// falls back to embedded data when override is missing
+	jump, err := m.Read("sounds/jump.wav")
+	assert.NoError(t, err)
+	assert.Equal(t, "embedded jump", string(jump))
+}
+
+func Test_Read_missing(t *testing.T) {
+	m := NewManager(newEmbedded(), "")
+
+	_, err := m.Read("fonts/missing.ttf")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+// Package audio plays sound effects and music of the game.
+package audio
+
+const (
+	// Jump is played when Hero jumps.
+	Jump Sound = iota
+	// Collect is played when Hero collects a Trove.
+	Collect
+	// Fall is played when Hero falls into a Pit.
+	Fall
+	// Spotted is played when an Enemy spots Hero.
+	Spotted
+	// Death is played when Hero dies.
+	Death
+)
+
+var (
+	typeNames = map[Sound]string{
+		Jump:    "Jump",
+		Collect: "Collect",
+		Fall:    "Fall",
+		Spotted: "Spotted",
+		Death:   "Death",
+	}
+)
+
+// Sound is a type of sound effect.
+type Sound byte
+
+func (s Sound) String() string {
+	if s < Jump || s > Death {
+		return "Unknown"
+	}
+	return typeNames[s]
+}
+
+// Player plays sound effects and music.
+type Player interface {
+	// Play plays sound effect once.
+	Play(s Sound)
+	// PlayMusic loops background music of the given level.
+	PlayMusic(level int)
+	// StopMusic stops background music.
+	StopMusic()
+	// Destroy releases audio resources.
+	Destroy()
+}
+
+// Settings holds audio settings.
+type Settings struct {
+	// Mute disables audio completely.
+	Mute bool
+	// MusicVolume is a volume of background music in range of [0, 100].
+	MusicVolume int
+	// EffectsVolume is a volume of sound effects in range of [0, 100].
+	EffectsVolume int
+}
+
+// DefaultSettings returns default audio settings.
+func DefaultSettings() Settings {
+	return Settings{
+		MusicVolume:   60,
+		EffectsVolume: 100,
+	}
+}
+
+// scale converts volume in percents into a volume in range of [0, max].
+func scale(volume, max int) int {
+	if volume < 0 {
+		volume = 0
+	}
+	if volume > 100 {
+		volume = 100
+	}
+	return volume * max / 100
+}
+
+// Silent is a Player, which plays nothing, e.g. in tests or when audio device is not available.
+type Silent struct{}
+
+// Play ...
+func (Silent) Play(s Sound) {}
+
+// PlayMusic ...
+func (Silent) PlayMusic(level int) {}
+
+// StopMusic ...
+func (Silent) StopMusic() {}
+
+// Destroy ...
+func (Silent) Destroy() {}
@@ -0,0 +1,26 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scale(t *testing.T) {
+	assert.Equal(t, 0, scale(-10, 128))
+	assert.Equal(t, 0, scale(0, 128))
+	assert.Equal(t, 64, scale(50, 128))
+	assert.Equal(t, 128, scale(100, 128))
+	assert.Equal(t, 128, scale(150, 128))
+}
+
+func Test_Silent(t *testing.T) {
+	var p Player = Silent{}
+
+	assert.NotPanics(t, func() {
+		p.PlayMusic(1)
+		p.Play(Jump)
+		p.StopMusic()
+		p.Destroy()
+	})
+}
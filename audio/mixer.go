@@ -0,0 +1,150 @@
+package audio
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	mix "github.com/veandco/go-sdl2/mix"
+
+	"github.com/smeshkov/trovehero/assets"
+)
+
+var (
+	effectFiles = map[Sound]string{
+		Jump:    "sounds/jump.wav",
+		Collect: "sounds/collect.wav",
+		Fall:    "sounds/fall.wav",
+		Spotted: "sounds/spotted.wav",
+		Death:   "sounds/death.wav",
+	}
+
+	// music tracks are cycled through levels
+	musicFiles = []string{
+		"music/level-1.wav",
+		"music/level-2.wav",
+	}
+)
+
+// Mixer is a Player backed by SDL_mixer.
+type Mixer struct {
+	mu sync.Mutex
+
+	effects map[Sound]*mix.Chunk
+	music   []*mix.Music
+
+	// index of currently playing music track or -1
+	track int
+}
+
+// NewMixer opens audio device and loads all sounds from the given assets.
+func NewMixer(a *assets.Manager, s Settings) (*Mixer, error) {
+	if err := mix.OpenAudio(mix.DEFAULT_FREQUENCY, mix.DEFAULT_FORMAT, mix.DEFAULT_CHANNELS, mix.DEFAULT_CHUNKSIZE); err != nil {
+		return nil, fmt.Errorf("could not open audio: %w", err)
+	}
+
+	m := &Mixer{
+		effects: make(map[Sound]*mix.Chunk),
+		track:   -1,
+	}
+
+	for snd, name := range effectFiles {
+		rw, err := a.RWops(name)
+		if err != nil {
+			m.Destroy()
+			return nil, err
+		}
+		c, err := mix.LoadWAVRW(rw, true)
+		if err != nil {
+			m.Destroy()
+			return nil, fmt.Errorf("could not load sound %s: %w", name, err)
+		}
+		m.effects[snd] = c
+	}
+
+	for _, name := range musicFiles {
+		rw, err := a.RWops(name)
+		if err != nil {
+			m.Destroy()
+			return nil, err
+		}
+		mus, err := mix.LoadMUSRW(rw, 1)
+		if err != nil {
+			m.Destroy()
+			return nil, fmt.Errorf("could not load music %s: %w", name, err)
+		}
+		m.music = append(m.music, mus)
+	}
+
+	// -1 sets volume for all channels
+	mix.Volume(-1, scale(s.EffectsVolume, mix.MAX_VOLUME))
+	mix.VolumeMusic(scale(s.MusicVolume, mix.MAX_VOLUME))
+
+	return m, nil
+}
+
+// Play plays sound effect once on the first free channel.
+func (m *Mixer) Play(s Sound) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.effects[s]
+	if !ok {
+		return
+	}
+	if _, err := c.Play(-1, 0); err != nil {
+		// all channels are busy, sound is skipped
+		log.Printf("could not play sound %s: %v", s, err)
+	}
+}
+
+// PlayMusic loops music track of the given level, keeps playing if the track is already on.
+func (m *Mixer) PlayMusic(level int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.music) == 0 {
+		return
+	}
+
+	track := level % len(m.music)
+	if track < 0 {
+		track += len(m.music)
+	}
+	if track == m.track {
+		return
+	}
+
+	// -1 loops forever
+	if err := m.music[track].Play(-1); err != nil {
+		log.Printf("could not play music: %v", err)
+		return
+	}
+	m.track = track
+}
+
+// StopMusic stops background music.
+func (m *Mixer) StopMusic() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mix.HaltMusic()
+	m.track = -1
+}
+
+// Destroy frees loaded sounds and closes audio device.
+func (m *Mixer) Destroy() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mix.HaltMusic()
+	for _, c := range m.effects {
+		c.Free()
+	}
+	for _, mus := range m.music {
+		mus.Free()
+	}
+	m.effects = nil
+	m.music = nil
+	mix.CloseAudio()
+}
@@ -0,0 +1,50 @@
+package bt
+
+// Blackboard is a memory of a behaviour tree, which is shared by its nodes and kept in between ticks.
+type Blackboard struct {
+	values map[string]interface{}
+}
+
+// NewBlackboard creates new instance of Blackboard.
+func NewBlackboard() *Blackboard {
+	return &Blackboard{values: make(map[string]interface{})}
+}
+
+// Get returns value of the "key" and whether it is set.
+func (b *Blackboard) Get(key string) (interface{}, bool) {
+	v, ok := b.values[key]
+	return v, ok
+}
+
+// Set sets value of the "key".
+func (b *Blackboard) Set(key string, v interface{}) {
+	b.values[key] = v
+}
+
+// Delete removes the "key".
+func (b *Blackboard) Delete(key string) {
+	delete(b.values, key)
+}
+
+// Clear removes all the keys.
+func (b *Blackboard) Clear() {
+	b.values = make(map[string]interface{})
+}
+
+// Bool returns value of the "key" or false if it is not a bool.
+func (b *Blackboard) Bool(key string) bool {
+	v, _ := b.values[key].(bool)
+	return v
+}
+
+// Int returns value of the "key" or zero if it is not an int.
+func (b *Blackboard) Int(key string) int {
+	v, _ := b.values[key].(int)
+	return v
+}
+
+// Int64 returns value of the "key" or zero if it is not an int64.
+func (b *Blackboard) Int64(key string) int64 {
+	v, _ := b.values[key].(int64)
+	return v
+}
@@ -0,0 +1,156 @@
+// Package bt is a small behaviour tree engine.
+// A tree is ticked from the root on every update, composite nodes tick their children
+// in order and leaves do the actual work, state between ticks is kept in a Blackboard.
+package bt
+
+import (
+	"github.com/smeshkov/trovehero/types/status"
+)
+
+// Node is a node of a behaviour tree.
+type Node interface {
+	// Name of the node, as shown in a Trace.
+	Name() string
+	// Tick runs the node once.
+	Tick(ctx *Context) status.Type
+}
+
+// Context is passed down the tree on every tick.
+type Context struct {
+	Board *Blackboard
+	// Trace, if not nil, records every ticked node.
+	Trace *Trace
+
+	depth int
+}
+
+// Tick ticks the "root" of a tree with the given Blackboard "b" and returns its status,
+// if "trace" is not nil it gets reset and filled with the ticked nodes.
+func Tick(root Node, b *Blackboard, trace *Trace) status.Type {
+	if trace != nil {
+		trace.Reset()
+	}
+	return tick(root, &Context{Board: b, Trace: trace})
+}
+
+// tick ticks the node "n" one level deeper in the tree and records it to the trace.
+func tick(n Node, ctx *Context) status.Type {
+	i := ctx.Trace.begin(ctx.depth, n.Name())
+	ctx.depth++
+	s := n.Tick(ctx)
+	ctx.depth--
+	ctx.Trace.end(i, s)
+	return s
+}
+
+// Leaf does the actual work of a tree, e.g. checks a condition or performs an action.
+type Leaf func(ctx *Context) status.Type
+
+type leaf struct {
+	name string
+	fn   Leaf
+}
+
+// NewLeaf creates a Node out of the given function "fn".
+func NewLeaf(name string, fn Leaf) Node {
+	return &leaf{name: name, fn: fn}
+}
+
+func (l *leaf) Name() string { return l.name }
+
+func (l *leaf) Tick(ctx *Context) status.Type { return l.fn(ctx) }
+
+// Condition turns "fn" into a Leaf, which succeeds when "fn" returns true and fails otherwise.
+func Condition(fn func(ctx *Context) bool) Leaf {
+	return func(ctx *Context) status.Type {
+		if fn(ctx) {
+			return status.Success
+		}
+		return status.Failure
+	}
+}
+
+type sequence struct {
+	children []Node
+}
+
+// Sequence ticks its children in order until one of them does not succeed,
+// it succeeds if all the children do.
+func Sequence(children ...Node) Node {
+	return &sequence{children: children}
+}
+
+func (s *sequence) Name() string { return "sequence" }
+
+func (s *sequence) Tick(ctx *Context) status.Type {
+	for _, c := range s.children {
+		if st := tick(c, ctx); st != status.Success {
+			return st
+		}
+	}
+	return status.Success
+}
+
+type selector struct {
+	children []Node
+}
+
+// Selector ticks its children in order until one of them does not fail,
+// it fails if all the children do.
+func Selector(children ...Node) Node {
+	return &selector{children: children}
+}
+
+func (s *selector) Name() string { return "selector" }
+
+func (s *selector) Tick(ctx *Context) status.Type {
+	for _, c := range s.children {
+		if st := tick(c, ctx); st != status.Failure {
+			return st
+		}
+	}
+	return status.Failure
+}
+
+type decorator struct {
+	name  string
+	child Node
+	fn    func(status.Type) status.Type
+}
+
+func (d *decorator) Name() string { return d.name }
+
+func (d *decorator) Tick(ctx *Context) status.Type { return d.fn(tick(d.child, ctx)) }
+
+// Not swaps success and failure of its child.
+func Not(child Node) Node {
+	return &decorator{name: "not", child: child, fn: func(s status.Type) status.Type {
+		switch s {
+		case status.Success:
+			return status.Failure
+		case status.Failure:
+			return status.Success
+		}
+		return s
+	}}
+}
+
+// Succeed succeeds whenever its child is done.
+func Succeed(child Node) Node {
+	return &decorator{name: "succeed", child: child, fn: func(s status.Type) status.Type {
+		if s == status.Running {
+			return s
+		}
+		return status.Success
+	}}
+}
+
+// Fail fails whenever its child is done.
+func Fail(child Node) Node {
+	return &decorator{name: "fail", child: child, fn: func(s status.Type) status.Type {
+		if s == status.Running {
+			return s
+		}
+		return status.Failure
+	}}
+}
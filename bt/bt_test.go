@@ -0,0 +1,101 @@
+package bt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smeshkov/trovehero/types/status"
+)
+
+func constant(s status.Type) Leaf {
+	return func(*Context) status.Type { return s }
+}
+
+var testLeaves = Leaves{
+	"ok":      constant(status.Success),
+	"fail":    constant(status.Failure),
+	"running": constant(status.Running),
+	"count": func(ctx *Context) status.Type {
+		ctx.Board.Set("count", ctx.Board.Int("count")+1)
+		return status.Success
+	},
+}
+
+func build(t *testing.T, data string) Node {
+	s, err := Parse([]byte(data))
+	assert.NoError(t, err)
+	n, err := Build(s, testLeaves)
+	assert.NoError(t, err)
+	return n
+}
+
+func Test_Tick(t *testing.T) {
+	tests := []struct {
+		name string
+		tree string
+		want status.Type
+	}{
+		{"sequence succeeds", `{"type": "sequence", "children": [{"leaf": "ok"}, {"leaf": "ok"}]}`, status.Success},
+		{"sequence fails", `{"type": "sequence", "children": [{"leaf": "ok"}, {"leaf": "fail"}]}`, status.Failure},
+		{"sequence runs", `{"type": "sequence", "children": [{"leaf": "running"}, {"leaf": "fail"}]}`, status.Running},
+		{"selector succeeds", `{"type": "selector", "children": [{"leaf": "fail"}, {"leaf": "ok"}]}`, status.Success},
+		{"selector fails", `{"type": "selector", "children": [{"leaf": "fail"}, {"leaf": "fail"}]}`, status.Failure},
+		{"selector runs", `{"type": "selector", "children": [{"leaf": "running"}, {"leaf": "ok"}]}`, status.Running},
+		{"not", `{"type": "not", "children": [{"leaf": "ok"}]}`, status.Failure},
+		{"not running", `{"type": "not", "children": [{"leaf": "running"}]}`, status.Running},
+		{"succeed", `{"type": "succeed", "children": [{"leaf": "fail"}]}`, status.Success},
+		{"fail", `{"type": "fail", "children": [{"leaf": "ok"}]}`, status.Failure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tick(build(t, tt.tree), NewBlackboard(), nil))
+		})
+	}
+}
+
+func Test_Tick_blackboard(t *testing.T) {
+	n := build(t, `{"type": "sequence", "children": [{"leaf": "count"}, {"leaf": "count"}]}`)
+	b := NewBlackboard()
+
+	Tick(n, b, nil)
+	Tick(n, b, nil)
+
+	assert.Equal(t, 4, b.Int("count"))
+}
+
+func Test_Tick_trace(t *testing.T) {
+	n := build(t, `{"type": "selector", "children": [
+		{"type": "sequence", "children": [{"leaf": "ok"}, {"leaf": "fail"}, {"leaf": "ok"}]},
+		{"type": "not", "children": [{"leaf": "fail"}]}
+	]}`)
+	var trace Trace
+
+	Tick(n, NewBlackboard(), &trace)
+
+	assert.Equal(t, []string{
+		"selector:Success",
+		"sequence:Failure", "ok:Success", "fail:Failure",
+		"not:Success", "fail:Failure",
+	}, trace.Path())
+	assert.Equal(t, "selector Success\n  sequence Failure\n    ok Success\n    fail Failure\n  not Success\n    fail Failure\n", trace.String())
+
+	// trace only keeps the last tick
+	Tick(n, NewBlackboard(), &trace)
+	assert.Len(t, trace.Steps, 6)
+}
+
+func Test_Build_errors(t *testing.T) {
+	for _, data := range []string{
+		`{"leaf": "unknown"}`,
+		`{"type": "unknown", "children": [{"leaf": "ok"}]}`,
+		`{"type": "not", "children": [{"leaf": "ok"}, {"leaf": "ok"}]}`,
+		`{"type": "sequence", "children": [{"leaf": "unknown"}]}`,
+	} {
+		s, err := Parse([]byte(data))
+		assert.NoError(t, err)
+
+		_, err = Build(s, testLeaves)
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,72 @@
+package bt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Spec describes a tree as data, e.g.
+//
+//	{"type": "selector", "children": [
+//		{"type": "sequence", "children": [{"leaf": "seesHero"}, {"leaf": "chase"}]},
+//		{"leaf": "wander"}
+//	]}
+//
+// Type is one of "sequence", "selector", "not", "succeed" and "fail", it can be omitted for leaves.
+type Spec struct {
+	Type     string `json:"type,omitempty"`
+	Leaf     string `json:"leaf,omitempty"`
+	Children []Spec `json:"children,omitempty"`
+}
+
+// Leaves are leaves, which trees can be built of, by their names.
+type Leaves map[string]Leaf
+
+// Parse parses Spec from JSON "data".
+func Parse(data []byte) (Spec, error) {
+	var s Spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Spec{}, fmt.Errorf("could not parse tree: %w", err)
+	}
+	return s, nil
+}
+
+// Build builds a tree by its Spec "s" out of the given "leaves".
+func Build(s Spec, leaves Leaves) (Node, error) {
+	if s.Type == "" || s.Type == "leaf" {
+		fn, ok := leaves[s.Leaf]
+		if !ok {
+			return nil, fmt.Errorf("unknown leaf %q", s.Leaf)
+		}
+		return NewLeaf(s.Leaf, fn), nil
+	}
+
+	children := make([]Node, len(s.Children))
+	for i, c := range s.Children {
+		n, err := Build(c, leaves)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = n
+	}
+
+	switch s.Type {
+	case "sequence":
+		return Sequence(children...), nil
+	case "selector":
+		return Selector(children...), nil
+	case "not", "succeed", "fail":
+		if len(children) != 1 {
+			return nil, fmt.Errorf("%s must have exactly one child, got %d", s.Type, len(children))
+		}
+		switch s.Type {
+		case "not":
+			return Not(children[0]), nil
+		case "succeed":
+			return Succeed(children[0]), nil
+		}
+		return Fail(children[0]), nil
+	}
+
+	return nil, fmt.Errorf("unknown node type %q", s.Type)
+}
@@ -0,0 +1,61 @@
+package bt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smeshkov/trovehero/types/status"
+)
+
+// Step is a node ticked during a single tick of a tree.
+type Step struct {
+	// Depth of the node in the tree, root is at zero
+	Depth  int
+	Name   string
+	Status status.Type
+}
+
+// Trace records nodes ticked during a single tick of a tree in the order they were ticked.
+type Trace struct {
+	Steps []Step
+}
+
+// Reset clears the Trace.
+func (t *Trace) Reset() {
+	t.Steps = t.Steps[:0]
+}
+
+// Path returns names of the ticked nodes along with their statuses, e.g. "selector:Running".
+func (t Trace) Path() []string {
+	path := make([]string, len(t.Steps))
+	for i, s := range t.Steps {
+		path[i] = fmt.Sprintf("%s:%s", s.Name, s.Status)
+	}
+	return path
+}
+
+// String returns the Trace as an indented tree.
+func (t Trace) String() string {
+	var sb strings.Builder
+	for _, s := range t.Steps {
+		fmt.Fprintf(&sb, "%s%s %s\n", strings.Repeat("  ", s.Depth), s.Name, s.Status)
+	}
+	return sb.String()
+}
+
+// begin records a node before it is ticked, since its children follow it.
+func (t *Trace) begin(depth int, name string) int {
+	if t == nil {
+		return -1
+	}
+	t.Steps = append(t.Steps, Step{Depth: depth, Name: name})
+	return len(t.Steps) - 1
+}
+
+// end records status of the node recorded at "i".
+func (t *Trace) end(i int, s status.Type) {
+	if t == nil || i < 0 {
+		return
+	}
+	t.Steps[i].Status = s
+}
@@ -6,16 +6,80 @@ import (
 	"os"
 
 	"github.com/smeshkov/trovehero"
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/difficulty"
+	"github.com/smeshkov/trovehero/highscore"
 )
 
 var (
-	level = flag.Int("lvl", 0, "sets starting level, e.g. -lvl=2")
+	level     = flag.Int("lvl", 0, "sets starting level, e.g. -lvl=2")
+	assetsDir = flag.String("assets", "", "sets directory, which overrides embedded assets, e.g. -assets=./mods")
+	diff      = flag.String("difficulty", difficulty.Normal, "sets difficulty: easy, normal or hard, e.g. -difficulty=hard")
+	seed      = flag.Int64("seed", 0, "sets seed of the generated levels, e.g. -seed=42")
+	classic   = flag.Bool("classic", false, "makes every hit fatal, as in the classic mode")
+	mute      = flag.Bool("mute", false, "disables audio")
+	music     = flag.Int("music", audio.DefaultSettings().MusicVolume, "sets music volume in range of 0-100, e.g. -music=50")
+	sfx       = flag.Int("sfx", audio.DefaultSettings().EffectsVolume, "sets sound effects volume in range of 0-100, e.g. -sfx=50")
+	levelMap  = flag.String("map", "", "sets handmade level from levels of the assets to play instead of generated ones, e.g. -map=sample")
+	scores    = flag.String("scores", "", "sets file of the high-score table, e.g. -scores=./scores.json")
 )
 
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [scores]\n\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Runs the game or lists the high-score table with \"scores\".\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
-	if err := trovehero.Run(int8(*level)); err != nil {
+
+	switch flag.Arg(0) {
+	case "":
+	case "scores":
+		if err := listScores(*scores); err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(3)
+		}
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := trovehero.Run(trovehero.Config{
+		Level:      *level,
+		AssetsDir:  *assetsDir,
+		Seed:       *seed,
+		Difficulty: *diff,
+		Classic:    *classic,
+		Map:        *levelMap,
+		Scores:     *scores,
+		Audio: audio.Settings{
+			Mute:          *mute,
+			MusicVolume:   *music,
+			EffectsVolume: *sfx,
+		},
+	}); err != nil {
 		fmt.Fprintf(os.Stderr, "%v", err)
 		os.Exit(3)
 	}
 }
+
+// listScores prints the high-score table kept at "path" or in the user's data directory.
+func listScores(path string) error {
+	if path == "" {
+		p, err := highscore.DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = p
+	}
+	entries, err := highscore.NewStore(path).Load()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No high scores yet")
+		return nil
+	}
+	return highscore.Print(os.Stdout, entries)
+}
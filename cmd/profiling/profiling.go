@@ -9,6 +9,7 @@ import (
 	// "go.uber.org/zap"
 
 	"github.com/smeshkov/trovehero"
+	"github.com/smeshkov/trovehero/audio"
 )
 
 var (
@@ -38,7 +39,7 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
-	if err := trovehero.Run(); err != nil {
+	if err := trovehero.Run(trovehero.Config{Audio: audio.DefaultSettings()}); err != nil {
 		fmt.Fprintf(os.Stderr, "%v", err)
 		os.Exit(3)
 	}
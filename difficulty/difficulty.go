@@ -0,0 +1,198 @@
+// Package difficulty describes how the game gets harder from level to level.
+package difficulty
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/smeshkov/trovehero/assets"
+)
+
+const (
+	// Easy difficulty.
+	Easy = "easy"
+	// Normal difficulty.
+	Normal = "normal"
+	// Hard difficulty.
+	Hard = "hard"
+)
+
+// Curve is a value, which grows linearly with a level up to the Max, zero Max means no limit.
+type Curve struct {
+	Base     float64 `json:"base"`
+	PerLevel float64 `json:"perLevel"`
+	Max      float64 `json:"max"`
+}
+
+// At returns value of the Curve at the given level "lvl".
+func (c Curve) At(lvl int) float64 {
+	v := c.Base + c.PerLevel*float64(lvl)
+	if c.Max != 0 && v > c.Max {
+		v = c.Max
+	}
+	return v
+}
+
+// Profile is a data driven difficulty of the game.
+type Profile struct {
+	Name string `json:"name"`
+
+	Enemies       Curve `json:"enemies"`
+	EnemySpeed    Curve `json:"enemySpeed"`
+	SightDistance Curve `json:"sightDistance"`
+	// FieldOfView of enemies is in degrees
+	FieldOfView Curve `json:"fieldOfView"`
+	// TurnRate of enemies is in degrees per tick
+	TurnRate Curve `json:"turnRate"`
+	// Archetypes are relative weights of enemies' archetypes, e.g. "hunter"
+	Archetypes map[string]Curve `json:"archetypes"`
+
+	Pits     Curve `json:"pits"`
+	PitSize  Curve `json:"pitSize"`
+	PitDepth Curve `json:"pitDepth"`
+	// Traps are relative weights of pits' kinds, e.g. "moving"
+	Traps map[string]Curve `json:"traps"`
+
+	// Hazards are spikes, plates, boulders and fire
+	Hazards Curve `json:"hazards"`
+	// HazardKinds are relative weights of hazards' kinds, e.g. "spikes"
+	HazardKinds map[string]Curve `json:"hazardKinds"`
+
+	Troves Curve `json:"troves"`
+	// TroveKinds are relative weights of troves' kinds, e.g. "shy"
+	TroveKinds map[string]Curve `json:"troveKinds"`
+	// OptionalTroves out of all troves give a bonus score, but aren't required to unlock the exit
+	OptionalTroves Curve `json:"optionalTroves"`
+	// Rooms hold troves behind locked doors
+	Rooms Curve `json:"rooms"`
+
+	// Zones of ice, mud and sand
+	Zones    Curve `json:"zones"`
+	ZoneSize Curve `json:"zoneSize"`
+
+	// Health of Hero, zero means the classic mode, where every hit is fatal
+	Health        Curve `json:"health"`
+	HealthPickups Curve `json:"healthPickups"`
+
+	// Pickups are power-ups and gems
+	Pickups Curve `json:"pickups"`
+	// Items are relative weights of pickups' items, e.g. "gem"
+	Items map[string]Curve `json:"items"`
+
+	// TimeLimit is in seconds, zero means no limit
+	TimeLimit Curve `json:"timeLimit"`
+}
+
+// Settings are values of the Profile at a certain level.
+type Settings struct {
+	Enemies       int
+	EnemySpeed    float32
+	SightDistance int32
+	// FieldOfView in degrees
+	FieldOfView float64
+	// TurnRate in degrees per tick
+	TurnRate float64
+	// Archetypes are relative weights of enemies' archetypes
+	Archetypes map[string]float64
+
+	Pits int
+	// PitSize is the largest size of a pit's side
+	PitSize int32
+	// PitDepth is the largest depth of a pit
+	PitDepth int8
+	// Traps are relative weights of pits' kinds
+	Traps map[string]float64
+
+	Hazards int
+	// HazardKinds are relative weights of hazards' kinds
+	HazardKinds map[string]float64
+
+	Troves int
+	// TroveKinds are relative weights of troves' kinds
+	TroveKinds     map[string]float64
+	OptionalTroves int
+	Rooms          int
+
+	Zones int
+	// ZoneSize is the largest size of a zone's side
+	ZoneSize int32
+
+	// Health of Hero, zero means that every hit is fatal
+	Health        int
+	HealthPickups int
+
+	Pickups int
+	// Items are relative weights of pickups' items
+	Items map[string]float64
+
+	// TimeLimit to complete the level, zero means no limit
+	TimeLimit time.Duration
+}
+
+// At returns Settings of the given level "lvl".
+func (p *Profile) At(lvl int) Settings {
+	if lvl < 0 {
+		lvl = 0
+	}
+	return Settings{
+		Archetypes:     weights(p.Archetypes, lvl),
+		Enemies:        count(p.Enemies.At(lvl)),
+		EnemySpeed:     float32(p.EnemySpeed.At(lvl)),
+		SightDistance:  int32(p.SightDistance.At(lvl)),
+		FieldOfView:    p.FieldOfView.At(lvl),
+		TurnRate:       p.TurnRate.At(lvl),
+		Pits:           count(p.Pits.At(lvl)),
+		PitSize:        int32(p.PitSize.At(lvl)),
+		PitDepth:       int8(math.Min(math.MaxInt8, p.PitDepth.At(lvl))),
+		Traps:          weights(p.Traps, lvl),
+		Hazards:        count(p.Hazards.At(lvl)),
+		HazardKinds:    weights(p.HazardKinds, lvl),
+		Troves:         count(p.Troves.At(lvl)),
+		TroveKinds:     weights(p.TroveKinds, lvl),
+		OptionalTroves: count(p.OptionalTroves.At(lvl)),
+		Rooms:          count(p.Rooms.At(lvl)),
+		Zones:          count(p.Zones.At(lvl)),
+		ZoneSize:       int32(p.ZoneSize.At(lvl)),
+		Health:         count(p.Health.At(lvl)),
+		HealthPickups:  count(p.HealthPickups.At(lvl)),
+		Pickups:        count(p.Pickups.At(lvl)),
+		Items:          weights(p.Items, lvl),
+		TimeLimit:      time.Duration(p.TimeLimit.At(lvl) * float64(time.Second)),
+	}
+}
+
+// weights returns positive weights of the given curves at the level "lvl".
+func weights(curves map[string]Curve, lvl int) map[string]float64 {
+	w := make(map[string]float64, len(curves))
+	for name, c := range curves {
+		if v := c.At(lvl); v > 0 {
+			w[name] = v
+		}
+	}
+	return w
+}
+
+// count rounds down, but never below zero.
+func count(v float64) int {
+	return int(math.Max(0, math.Floor(v)))
+}
+
+// Load loads Profile with the given "name" from the assets, e.g. "difficulty/normal.json".
+func Load(a *assets.Manager, name string) (*Profile, error) {
+	data, err := a.Read(fmt.Sprintf("difficulty/%s.json", name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown difficulty %s: %w", name, err)
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse difficulty %s: %w", name, err)
+	}
+	if p.Name == "" {
+		p.Name = name
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,55 @@
+package difficulty
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smeshkov/trovehero/assets"
+)
+
+func Test_Curve_At(t *testing.T) {
+	c := Curve{Base: 1, PerLevel: 0.5, Max: 3}
+
+	assert.Equal(t, 1.0, c.At(0))
+	assert.Equal(t, 2.0, c.At(2))
+	assert.Equal(t, 3.0, c.At(10))
+	assert.Equal(t, 51.0, Curve{Base: 1, PerLevel: 0.5}.At(100))
+}
+
+func Test_Profile_At_high_level(t *testing.T) {
+	p := &Profile{
+		Enemies:  Curve{Base: 1, PerLevel: 1},
+		PitDepth: Curve{Base: 100, PerLevel: 1},
+	}
+
+	s := p.At(1000)
+
+	assert.Equal(t, 1001, s.Enemies)
+	assert.Equal(t, int8(127), s.PitDepth)
+}
+
+func Test_Load(t *testing.T) {
+	a := assets.NewManager(os.DirFS("../res"), "")
+
+	for _, name := range []string{Easy, Normal, Hard} {
+		p, err := Load(a, name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, p.Name)
+		assert.True(t, p.At(0).Troves > 0, "%s has no troves", name)
+		assert.NotEmpty(t, p.At(0).Archetypes, "%s has no archetypes", name)
+	}
+
+	hard, _ := Load(a, Hard)
+	assert.Equal(t, 45*time.Second, hard.At(0).TimeLimit)
+}
+
+func Test_Load_unknown(t *testing.T) {
+	a := assets.NewManager(os.DirFS("../res"), "")
+
+	_, err := Load(a, "impossible")
+
+	assert.Error(t, err)
+}
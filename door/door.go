@@ -0,0 +1,70 @@
+package door
+
+import (
+	"sync"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/key"
+	"github.com/smeshkov/trovehero/world"
+)
+
+// Size is a width of a door, wide enough for the Hero to pass.
+const Size = 70
+
+// Door blocks the way, until it is opened with the key of its color.
+type Door struct {
+	mu sync.RWMutex
+
+	ID string
+
+	// Key opens the Door
+	Key key.Type
+
+	X, Y int32
+	W, H int32
+
+	world *world.World
+
+	open bool
+}
+
+// NewDoor creates new instance of closed Door, which opens with the key "k".
+func NewDoor(id string, x, y, width, height int32, k key.Type, w *world.World) *Door {
+	return &Door{
+		ID:    id,
+		Key:   k,
+		X:     x,
+		Y:     y,
+		W:     width,
+		H:     height,
+		world: w,
+	}
+}
+
+// Paint ...
+func (d *Door) Paint(r *sdl.Renderer) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	c := key.Colors[d.Key]
+	r.SetDrawColor(c.R, c.G, c.B, c.A)
+	r.FillRect(&sdl.Rect{X: d.X, Y: d.Y, W: d.W, H: d.H})
+	r.SetDrawColor(0, 0, 0, 255)
+
+	return nil
+}
+
+// Open opens the Door.
+func (d *Door) Open() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.open = true
+}
+
+// IsOpen tells whether the Door has been opened already or not.
+func (d *Door) IsOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.open
+}
@@ -0,0 +1,74 @@
+package enemy
+
+import (
+	"fmt"
+
+	"github.com/smeshkov/trovehero/world"
+)
+
+const (
+	// Patroller walks along the route.
+	Patroller Archetype = "patroller"
+	// Guard is slow, sees wide and stays at its post.
+	Guard Archetype = "guard"
+	// Hunter is fast, sees far but narrow and remembers where Hero was seen.
+	Hunter Archetype = "hunter"
+	// Sentry stands still and looks around.
+	Sentry Archetype = "sentry"
+)
+
+// Archetype is a kind of Enemy with distinct properties and behaviour.
+type Archetype string
+
+// archetype scales Props of an Enemy, its behaviour is defined by a tree, see Trees.
+type archetype struct {
+	speed    float32
+	distance float32
+	fov      float64
+	turn     float64
+}
+
+var archetypes = map[Archetype]archetype{
+	Patroller: {speed: 1, distance: 1, fov: 1, turn: 1},
+	Guard:     {speed: 0.6, distance: 0.9, fov: 1.5, turn: 0.8},
+	Hunter:    {speed: 1.5, distance: 1.6, fov: 0.5, turn: 1.5},
+	Sentry:    {speed: 0, distance: 1.2, fov: 1, turn: 0.5},
+}
+
+// Valid tells whether the Archetype is known.
+func (a Archetype) Valid() bool {
+	_, ok := archetypes[a]
+	return ok
+}
+
+// Props returns properties of the Archetype, derived from the "base" ones.
+func (a Archetype) Props(base Props) Props {
+	t, ok := archetypes[a]
+	if !ok {
+		return base
+	}
+	return Props{
+		MaxMoveSpeed:  base.MaxMoveSpeed * t.speed,
+		SightDistance: int32(float32(base.SightDistance) * t.distance),
+		FieldOfView:   base.FieldOfView * t.fov,
+		TurnRate:      base.TurnRate * t.turn,
+	}
+}
+
+// Spawn creates an Enemy of the given Archetype "a" in given coordinates, which is driven by the tree of the Archetype,
+// its properties are derived from the "base" ones, "route" is followed by patrollers.
+func (t Trees) Spawn(id string, x, y int32, a Archetype, base Props, route Route, w *world.World) (*Enemy, error) {
+	if !a.Valid() {
+		return nil, fmt.Errorf("unknown enemy archetype %q", a)
+	}
+	s, ok := t[a]
+	if !ok {
+		return nil, fmt.Errorf("no tree of enemy archetype %q", a)
+	}
+	tree, err := NewTree(s, route)
+	if err != nil {
+		return nil, fmt.Errorf("could not build tree of %s: %w", a, err)
+	}
+	e := &Enemy{ID: id, props: a.Props(base), behaviour: tree}
+	return e.setDefaults(x, y, enemyWidth, enemyHeight, w), nil
+}
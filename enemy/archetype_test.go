@@ -0,0 +1,81 @@
+package enemy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func testTrees(t *testing.T) Trees {
+	trees, err := LoadTrees(assets.NewManager(os.DirFS("../res"), ""))
+	assert.NoError(t, err)
+	return trees
+}
+
+func Test_Archetype_Props(t *testing.T) {
+	base := DefaultProps()
+
+	assert.Equal(t, base, Patroller.Props(base))
+	assert.Greater(t, Hunter.Props(base).MaxMoveSpeed, base.MaxMoveSpeed)
+	assert.Greater(t, Hunter.Props(base).SightDistance, base.SightDistance)
+	assert.Greater(t, Guard.Props(base).FieldOfView, base.FieldOfView)
+	assert.Zero(t, Sentry.Props(base).MaxMoveSpeed)
+}
+
+func Test_Spawn_unknown(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	_, err := testTrees(t).Spawn("enemy-0", 100, 100, Archetype("dragon"), DefaultProps(), Route{}, w)
+
+	assert.Error(t, err)
+	assert.False(t, Archetype("dragon").Valid())
+}
+
+func Test_Spawn_sentry_stands_still(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Sentry, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+	w.Register(e.ID, kind.Enemy, &sdl.Rect{X: 500, Y: 500, W: enemyWidth, H: enemyHeight})
+
+	for i := 0; i < lookAround*2; i++ {
+		e.Update()
+	}
+
+	pos, ok := w.Entity("enemy-0")
+	assert.True(t, ok)
+	assert.Equal(t, sdl.Rect{X: 500, Y: 500, W: enemyWidth, H: enemyHeight}, pos.Bounds)
+}
+
+func Test_Spawn_patroller_follows_route(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	route := Route{Waypoints: []Waypoint{{X: 525, Y: 525}, {X: 725, Y: 525}}}
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Patroller, DefaultProps(), route, w)
+	assert.NoError(t, err)
+
+	reached := false
+	for i := 0; i < 200 && !reached; i++ {
+		e.Update()
+		c := e.center()
+		reached = c.X >= 720 && c.Y == 525
+	}
+
+	assert.True(t, reached)
+}
+
+func Test_Trace_hunter_chases(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Hunter, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+
+	e.seesHero = true
+	e.Update()
+
+	assert.Equal(t, []string{"selector:Running", "sequence:Running", "seesHero:Success", "chase:Running"}, e.Trace().Path())
+}
@@ -0,0 +1,114 @@
+package enemy
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/alert"
+	"github.com/smeshkov/trovehero/types/shape"
+	"github.com/smeshkov/trovehero/vec"
+)
+
+// lookAround is a number of ticks in between turns of an Enemy, which stands still.
+const lookAround = 150
+
+// Behaviour decides how an Enemy acts.
+type Behaviour interface {
+	// Start is called when Enemy (re)spawns.
+	Start(e *Enemy)
+	// Update is called on every tick, it directs Enemy and tells whether Enemy should move.
+	Update(e *Enemy) bool
+}
+
+// Wander makes Enemy to walk around and turn away from the edges of the world.
+type Wander struct{}
+
+// Start ...
+func (Wander) Start(e *Enemy) {}
+
+// Update ...
+func (Wander) Update(e *Enemy) bool {
+	e.directionCheck()
+	return true
+}
+
+// lookAround turns Enemy clockwise from time to time.
+func (e *Enemy) lookAround() {
+	if e.time%lookAround == 0 {
+		e.turn()
+	}
+}
+
+// turn turns Enemy clockwise by a right angle.
+func (e *Enemy) turn() {
+	e.heading = shape.Angle(e.heading + math.Pi/2)
+}
+
+// headTo directs Enemy towards the given point and tells whether Enemy has already reached it.
+func (e *Enemy) headTo(p sdl.Point) bool {
+	c := e.center()
+	dx, dy := p.X-c.X, p.Y-c.Y
+
+	// close enough, if the next step would overshoot the point
+	reach := int32(e.maxMoveSpeed) + 1
+	if abs(dx) <= reach && abs(dy) <= reach {
+		// stop right there instead of sliding past the point
+		e.vel = vec.Vec{}
+		return true
+	}
+
+	e.faceTo(p)
+	return false
+}
+
+// faceTo turns Enemy towards the given point.
+func (e *Enemy) faceTo(p sdl.Point) {
+	c := e.center()
+	if p == c {
+		return
+	}
+	e.heading = math.Atan2(float64(p.Y-c.Y), float64(p.X-c.X))
+}
+
+// remembersHero tells whether Enemy has lost sight of Hero recently.
+func (e *Enemy) remembersHero() bool {
+	return e.lostAt > 0 && e.time-e.lostAt <= enemyMemory
+}
+
+// alarmed tells whether there is alarm close enough to Enemy to come and check.
+func (e *Enemy) alarmed() (sdl.Point, bool) {
+	a := e.world.Alert()
+	if a.Level != alert.Alarm {
+		return sdl.Point{}, false
+	}
+	c := e.center()
+	dx, dy := int64(a.LastKnown.X-c.X), int64(a.LastKnown.Y-c.Y)
+	return a.LastKnown, dx*dx+dy*dy <= alertRadius*alertRadius
+}
+
+// hearsNoise tells whether Enemy has heard a noise recently.
+func (e *Enemy) hearsNoise() bool {
+	return e.heardAt > 0 && e.time-e.heardAt <= noiseMemory
+}
+
+// forgetNoise makes Enemy to forget where a noise was heard.
+func (e *Enemy) forgetNoise() {
+	e.heardAt = 0
+}
+
+// forgetHero makes Enemy to forget where Hero was seen.
+func (e *Enemy) forgetHero() {
+	e.lostAt = 0
+}
+
+func (e *Enemy) center() sdl.Point {
+	return e.pos.Add(vec.Vec{X: float64(e.w) / 2, Y: float64(e.h) / 2}).Point()
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
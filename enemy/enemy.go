@@ -1,29 +1,63 @@
 package enemy
 
 import (
-	"fmt"
 	"math"
-	"os"
 	"sync"
 
 	"github.com/smeshkov/trovehero/world"
 
 	"github.com/veandco/go-sdl2/sdl"
 
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/bt"
+	"github.com/smeshkov/trovehero/hazard"
 	"github.com/smeshkov/trovehero/hero"
-	"github.com/smeshkov/trovehero/types/command"
 	"github.com/smeshkov/trovehero/types/direction"
+	"github.com/smeshkov/trovehero/types/kind"
 	"github.com/smeshkov/trovehero/types/shape"
+	"github.com/smeshkov/trovehero/vec"
 )
 
 const (
 	enemyMemory = 50
+	// alertRadius is a distance from the last known position of Hero, enemies converge from during alarm
+	alertRadius = 500
+	// noiseMemory is a number of ticks Enemy investigates a noise for
+	noiseMemory = 300
+	// stunTicks is a number of ticks Enemy stays stunned after an impact
+	stunTicks = 200
+	// enemyDamage is dealt to Hero by a touch of Enemy
+	enemyDamage = 1
 	enemyHeight = 50
 	enemyWidth  = 50
-	friction    = 0.2
-	airFriction = 0.1
 )
 
+// Props are properties of an Enemy, which vary with difficulty.
+type Props struct {
+	MaxMoveSpeed  float32
+	SightDistance int32
+	// FieldOfView is an angle of the vision cone in degrees
+	FieldOfView float64
+	// TurnRate is the largest turn in degrees per tick
+	TurnRate float64
+}
+
+// DefaultProps returns default properties of an Enemy.
+func DefaultProps() Props {
+	return Props{
+		MaxMoveSpeed:  2,
+		SightDistance: 150,
+		FieldOfView:   100,
+		TurnRate:      6,
+	}
+}
+
+// SightClearance keeps Hero out of the sight of an Enemy with the given Props in any direction,
+// when placing an Enemy.
+func SightClearance(p Props) world.Clearance {
+	return world.Clearance{Kind: kind.Hero, Margin: p.SightDistance}
+}
+
 // Enemy attacks Hero.
 type Enemy struct {
 	mu sync.RWMutex
@@ -33,137 +67,134 @@ type Enemy struct {
 	time int64
 
 	// properties
+	props        Props
 	maxMoveSpeed float32
 	maxJumpSpeed float32
 
 	// coordinates
 	altitude float64
 
-	// shape
-	x, y int32
+	// shape, position is the top left corner
+	pos  vec.Vec
 	w, h int32
 
 	// speed
-	vertSpeed float32
-	horSpeed  float32
-	altSpeed  float32
+	vel      vec.Vec
+	altSpeed float32
 
 	// AI
-	sightDistnace int32
-	sightWidth    int32
-	direction     direction.Type
+	sightDistance int32
+	// field of view and turn rate in radians
+	fov      float64
+	turnRate float64
+	// facing is a direction Enemy looks and moves in, it turns towards heading
+	facing    float64
+	heading   float64
+	behaviour Behaviour
+	seesHero  bool
+	lastSeen  sdl.Point
+	lostAt    int64
+	noise     sdl.Point
+	heardAt   int64
+	// stunnedUntil is a tick, till which Enemy can't see, move or harm Hero
+	stunnedUntil int64
 
 	// World
 	world *world.World
 }
 
-// NewEnemy creates new instance of Enemy in given coordinates.
-func NewEnemy(id string, x, y int32, w *world.World) *Enemy {
-	e := &Enemy{ID: id}
+// NewEnemy creates new instance of wandering Enemy with the given Props in given coordinates.
+func NewEnemy(id string, x, y int32, p Props, w *world.World) *Enemy {
+	e := &Enemy{ID: id, props: p, behaviour: Wander{}}
 	return e.setDefaults(x, y, enemyWidth, enemyHeight, w)
 }
 
 func (e *Enemy) setDefaults(x, y, width, height int32, w *world.World) *Enemy {
 	e.time = 0
 
-	e.maxMoveSpeed = 2
+	e.maxMoveSpeed = e.props.MaxMoveSpeed
 	e.maxJumpSpeed = 1
 
 	e.altitude = 0
 
 	// shape
-	e.x = x
-	e.y = y
+	e.pos = vec.Vec{X: float64(x), Y: float64(y)}
 	e.h = height
 	e.w = width
 
+	e.vel = vec.Vec{}
+
 	// AI
-	e.sightDistnace = 150
-	e.sightWidth = 350
-	e.direction = direction.Type(w.Rand.Int31n(3))
+	e.sightDistance = e.props.SightDistance
+	e.fov = e.props.FieldOfView * math.Pi / 180
+	e.turnRate = e.props.TurnRate * math.Pi / 180
+	e.facing = w.Rand.Float64() * 2 * math.Pi
+	e.heading = e.facing
+	e.seesHero = false
+	e.lostAt = 0
+	e.heardAt = 0
+	e.stunnedUntil = 0
 
 	// World
 	e.world = w
 
+	e.behaviour.Start(e)
+
 	return e
 }
 
-func (e *Enemy) canSeeHero(hero *sdl.Rect) bool {
-	var triangle [3]*sdl.Point
-
-	location := &sdl.Point{X: e.x + e.w/2, Y: e.y + e.h/2}
-
-	// create triangle view, depending on which direction is facing
-	switch e.direction {
-	case direction.North:
-		triangle = [3]*sdl.Point{
-			location,
-			{X: location.X - e.sightWidth/2, Y: location.Y - e.sightDistnace},
-			{X: location.X + e.sightWidth/2, Y: location.Y - e.sightDistnace},
-		}
-	case direction.East:
-		triangle = [3]*sdl.Point{
-			location,
-			{X: location.X + e.sightDistnace, Y: location.Y - e.sightWidth/2},
-			{X: location.X + e.sightDistnace, Y: location.Y + e.sightWidth/2},
-		}
-	case direction.South:
-		triangle = [3]*sdl.Point{
-			location,
-			{X: location.X - e.sightWidth/2, Y: location.Y + e.sightDistnace},
-			{X: location.X + e.sightWidth/2, Y: location.Y + e.sightDistnace},
-		}
-	case direction.West:
-		triangle = [3]*sdl.Point{
-			location,
-			{X: location.X - e.sightDistnace, Y: location.Y - e.sightWidth/2},
-			{X: location.X - e.sightDistnace, Y: location.Y + e.sightWidth/2},
-		}
+func (e *Enemy) canSeeHero(hero *sdl.Rect, visibility float64) bool {
+	if visibility <= 0 {
+		return false
 	}
-
-	// Vicinity of the enemy
-	viewPort := shape.NewTriangle(triangle, nil)
-
 	// Is hero in the vicinity of enemy
-	return viewPort.OverlapsRect(hero)
+	return e.sight(visibility).OverlapsRect(hero)
 }
 
-func (e *Enemy) directTo(x, y int32) {
-	if e.x+e.w > x {
-		e.direction = direction.West
-	}
-	if e.x < x {
-		e.direction = direction.East
-	}
-	if e.y+e.h > y {
-		e.direction = direction.North
+// sight returns vision cone of the Enemy for Hero with the given "visibility".
+func (e *Enemy) sight(visibility float64) *shape.Sector {
+	return &shape.Sector{
+		Apex:   e.center(),
+		Angle:  e.facing,
+		Width:  e.fov,
+		Radius: int32(float64(e.sightDistance) * visibility),
 	}
-	if e.y < y {
-		e.direction = direction.South
+}
+
+// turnToHeading turns Enemy towards its heading, but not faster than its turn rate.
+func (e *Enemy) turnToHeading() {
+	diff := shape.Angle(e.heading - e.facing)
+	if math.Abs(diff) <= e.turnRate {
+		e.facing = shape.Angle(e.heading)
+		return
 	}
+	e.facing = shape.Angle(e.facing + math.Copysign(e.turnRate, diff))
 }
 
+// directionCheck turns Enemy away from the edges of the world it is heading to.
 func (e *Enemy) directionCheck() {
+	d := direction.Of(e.heading)
+	r := e.footprint()
 
-	var changed bool
+	var changed, turned bool
 
-	for {
+	for i := 0; i < 4; i++ {
 		changed = false
 
-		if e.direction == direction.North && (e.y-e.sightDistnace/4) <= 0 {
-			e.direction = direction.East
+		if d == direction.North && (r.Y-e.sightDistance/4) <= 0 {
+			d = direction.East
 			changed = true
 		}
-		if e.direction == direction.East && (e.x+e.w+e.sightDistnace/4) >= e.world.W {
-			e.direction = direction.South
+		if d == direction.East && (r.X+r.W+e.sightDistance/4) >= e.world.W {
+			d = direction.South
 			changed = true
 		}
-		if e.direction == direction.South && (e.y+e.h+e.sightDistnace/4) >= e.world.H {
-			e.direction = direction.West
+		if d == direction.South && (r.Y+r.H+e.sightDistance/4) >= e.world.H {
+			d = direction.West
 			changed = true
 		}
-		if e.direction == direction.West && (e.x-e.sightDistnace/4) <= 0 {
-			e.direction = direction.North
+		if d == direction.West && (r.X-e.sightDistance/4) <= 0 {
+			d = direction.North
 			changed = true
 		}
 
@@ -171,8 +202,12 @@ func (e *Enemy) directionCheck() {
 			// we are done here, hence no changes happened
 			break
 		}
+		turned = true
 	}
 
+	if turned {
+		e.heading = d.Angle()
+	}
 }
 
 // Touch checks collision with Pit.
@@ -180,23 +215,28 @@ func (e *Enemy) Touch(h *hero.Hero) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	if e.isStunned() {
+		return
+	}
+
 	heroLoc := h.Location()
+	r := e.footprint()
 
-	if e.x > heroLoc.X+heroLoc.W { // too far right
+	if r.X > heroLoc.X+heroLoc.W { // too far right
 		return
 	}
-	if e.x+e.w < heroLoc.X { // too far left
+	if r.X+r.W < heroLoc.X { // too far left
 		return
 	}
-	if e.y > heroLoc.Y+heroLoc.H { // too far below
+	if r.Y > heroLoc.Y+heroLoc.H { // too far below
 		return
 	}
-	if e.y+e.h < heroLoc.Y { // to far above
+	if r.Y+r.H < heroLoc.Y { // to far above
 		return
 	}
 
-	// Hero is busted, so he dies
-	h.Die()
+	// Hero is busted
+	h.Hurt(enemyDamage, e.center())
 }
 
 // Watch checks if Enemy can see Hero.
@@ -204,11 +244,100 @@ func (e *Enemy) Watch(h *hero.Hero) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	heroLoc := h.Location()
+	// sneaking Hero is harder to spot
+	visibility := h.Visibility()
+
+	// look up world for the hero first, since the exact check is more expensive
+	var heroLoc *sdl.Rect
+	for _, ent := range e.world.EntitiesIn(e.sight(visibility).Bounds()) {
+		if ent.Kind == kind.Hero && ent.ID == h.ID {
+			heroLoc = &ent.Bounds
+			break
+		}
+	}
+
+	if heroLoc == nil || e.isStunned() || !e.canSeeHero(heroLoc, visibility) {
+		if e.seesHero {
+			e.seesHero = false
+			e.lostAt = e.time
+		}
+		return
+	}
 
-	if e.canSeeHero(heroLoc) {
-		e.directTo(heroLoc.X, heroLoc.Y)
+	if !e.seesHero {
+		e.seesHero = true
+		e.world.Audio.Play(audio.Spotted)
 	}
+	e.lastSeen = sdl.Point{X: heroLoc.X + heroLoc.W/2, Y: heroLoc.Y + heroLoc.H/2}
+	e.world.Spot(e.lastSeen)
+	e.faceTo(e.lastSeen)
+}
+
+// listen turns Enemy towards the nearest noise it can hear, unless Enemy sees Hero.
+func (e *Enemy) listen() {
+	if e.seesHero {
+		return
+	}
+
+	c := e.center()
+	var nearest *world.Noise
+	var nearestDist int64
+	for _, n := range e.world.NoisesAt(c) {
+		dx, dy := int64(n.Source.X-c.X), int64(n.Source.Y-c.Y)
+		if d := dx*dx + dy*dy; nearest == nil || d < nearestDist {
+			n := n
+			nearest, nearestDist = &n, d
+		}
+	}
+	if nearest == nil {
+		return
+	}
+
+	e.noise = nearest.Source
+	e.heardAt = e.time
+	e.faceTo(e.noise)
+}
+
+// feelImpact stuns Enemy, if it is hit by an impact.
+func (e *Enemy) feelImpact() {
+	if len(e.world.ImpactsAt(e.center())) == 0 {
+		return
+	}
+	e.stunnedUntil = e.time + stunTicks
+	e.vel = vec.Vec{}
+}
+
+// TouchHazard stuns Enemy, if it touches an active Hazard.
+func (e *Enemy) TouchHazard(hz *hazard.Hazard) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.isStunned() || !hz.IsActive() {
+		return
+	}
+	r, b := e.footprint(), hz.Bounds()
+	if !r.HasIntersection(b) {
+		return
+	}
+	e.stunnedUntil = e.time + stunTicks
+	e.vel = vec.Vec{}
+}
+
+// isStunned tells whether Enemy is stunned.
+func (e *Enemy) isStunned() bool {
+	return e.time < e.stunnedUntil
+}
+
+// Trace returns nodes of the Enemy's behaviour tree ticked during the last update,
+// it is empty if Enemy is not driven by a tree.
+func (e *Enemy) Trace() bt.Trace {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if t, ok := e.behaviour.(*Tree); ok {
+		return t.Trace()
+	}
+	return bt.Trace{}
 }
 
 // Update updates state of the Enemy.
@@ -218,17 +347,23 @@ func (e *Enemy) Update() {
 
 	e.time++
 
-	e.directionCheck()
+	if e.feelImpact(); e.isStunned() {
+		e.world.Move(e.ID, e.footprint(), e.altitude)
+		return
+	}
 
-	if cmd, err := command.ToCommand(e.direction); err == nil {
-		e.move(cmd)
-	} else {
-		fmt.Fprintf(os.Stderr, "enemy failed to convert direction to command: %v", err)
+	e.listen()
+	move := e.behaviour.Update(e)
+	e.turnToHeading()
+	if move {
+		e.move()
 	}
 
-	if e.horSpeed != 0 || e.vertSpeed != 0 {
+	if !e.vel.IsZero() {
 		e.handleMove()
 	}
+
+	e.world.Move(e.ID, e.footprint(), e.altitude)
 	// if h.crashingDepth == 0 && h.altSpeed != 0 {
 	// 	h.handleJump()
 	// }
@@ -237,44 +372,40 @@ func (e *Enemy) Update() {
 	// }
 }
 
-// move performes move command on an Enemy.
-func (e *Enemy) move(t command.Type) {
-	switch t {
-	case command.GoNorth:
-		e.vertSpeed = -e.maxMoveSpeed
-	case command.GoSouth:
-		e.vertSpeed = e.maxMoveSpeed
-	case command.GoWest:
-		e.horSpeed = -e.maxMoveSpeed
-	case command.GoEast:
-		e.horSpeed = e.maxMoveSpeed
+// move moves Enemy in the direction it faces, Enemy turns in place if it faces away from its heading.
+func (e *Enemy) move() {
+	if math.Abs(shape.Angle(e.heading-e.facing)) > math.Pi/4 {
+		return
 	}
+	e.vel = vec.FromAngle(e.facing).Scale(float64(e.maxMoveSpeed))
 }
 
 func (e *Enemy) handleMove() {
-	var frict float64
+	frict, speed := e.world.Physics.AirFriction, 1.0
 	if e.altitude == 0 {
-		frict = friction
-	} else {
-		frict = airFriction
+		surf := e.world.SurfaceAt(e.center())
+		frict, speed = e.world.Physics.Friction*surf.Friction, surf.Speed
 	}
 
-	if e.horSpeed != 0 {
-		e.x += int32(e.horSpeed)
-		if e.horSpeed > 0 {
-			e.horSpeed = float32(math.Max(0, float64(e.horSpeed)-frict))
-		} else {
-			e.horSpeed = float32(math.Min(0, float64(e.horSpeed)+frict))
-		}
+	// solid objects stop Enemy along the blocked axis
+	step := e.vel.Scale(speed)
+	if e.blocked(vec.Vec{X: step.X}) {
+		step.X, e.vel.X = 0, 0
 	}
-	if e.vertSpeed != 0 {
-		e.y += int32(e.vertSpeed)
-		if e.vertSpeed > 0 {
-			e.vertSpeed = float32(math.Max(0, float64(e.vertSpeed)-frict))
-		} else {
-			e.vertSpeed = float32(math.Min(0, float64(e.vertSpeed)+frict))
-		}
+	if e.blocked(vec.Vec{Y: step.Y}) {
+		step.Y, e.vel.Y = 0, 0
 	}
+	e.pos = e.pos.Add(step)
+	e.vel = e.vel.Shrink(frict)
+}
+
+// blocked tells whether a solid object stands in the way of the Enemy's "step".
+func (e *Enemy) blocked(step vec.Vec) bool {
+	if step.IsZero() {
+		return false
+	}
+	p := e.pos.Add(step).Point()
+	return e.world.Blocked(&sdl.Rect{X: p.X, Y: p.Y, W: e.w, H: e.h})
 }
 
 // Paint paints Enemy to window.
@@ -282,37 +413,42 @@ func (e *Enemy) Paint(r *sdl.Renderer) error {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	// fill new rectangle
-	r.SetDrawColor(160, 0, 0, 255)
+	// fill new rectangle, stunned Enemy is pale
+	if e.isStunned() {
+		r.SetDrawColor(200, 130, 130, 255)
+	} else {
+		r.SetDrawColor(160, 0, 0, 255)
+	}
 	r.FillRect(e.getShape())
 	r.SetDrawColor(0, 0, 0, 255)
 
 	return nil
 }
 
+// Bounds returns the current area of the Enemy.
+func (e *Enemy) Bounds() *sdl.Rect {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.getShape()
+}
+
 func (e *Enemy) getShape() *sdl.Rect {
+	r := e.footprint()
 	if e.altitude != 0 {
 		return &sdl.Rect{
-			X: e.x - int32(e.altitude/2),
-			Y: e.y - int32(e.altitude/2),
-			W: e.w + int32(e.altitude),
-			H: e.h + int32(e.altitude),
+			X: r.X - int32(e.altitude/2),
+			Y: r.Y - int32(e.altitude/2),
+			W: r.W + int32(e.altitude),
+			H: r.H + int32(e.altitude),
 		}
 	}
-	return &sdl.Rect{
-		X: e.x,
-		Y: e.y,
-		W: e.w,
-		H: e.h,
-	}
+	return r
 }
 
-// Restart restarts state of Enemy.
-func (e *Enemy) Restart() {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-	pos := e.world.RandomizePos(e.ID, enemyWidth, enemyHeight)
-	e.setDefaults(pos.X, pos.Y, pos.W, pos.H, e.world)
+// footprint returns area occupied by the Enemy on the ground, rounded to pixels.
+func (e *Enemy) footprint() *sdl.Rect {
+	p := e.pos.Point()
+	return &sdl.Rect{X: p.X, Y: p.Y, W: e.w, H: e.h}
 }
 
 // Destroy removes Enemy.
@@ -1,22 +1,25 @@
 package enemy
 
 import (
-	"github.com/smeshkov/trovehero/world"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/veandco/go-sdl2/sdl"
 
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/hazard"
 	"github.com/smeshkov/trovehero/types/direction"
+	"github.com/smeshkov/trovehero/vec"
+	"github.com/smeshkov/trovehero/world"
 )
 
 func newEnemy() *Enemy {
 	return &Enemy{
-		sightDistnace: 50,
-		sightWidth:    150,
-		direction:     direction.North,
-		x:             100,
-		y:             100,
+		sightDistance: 50,
+		fov:           math.Pi / 2,
+		facing:        direction.North.Angle(),
+		pos:           vec.Vec{X: 100, Y: 100},
 	}
 }
 
@@ -25,7 +28,7 @@ func Test_canSeeHero_true(t *testing.T) {
 
 	e := newEnemy()
 
-	canSee := e.canSeeHero(heroLoc)
+	canSee := e.canSeeHero(heroLoc, 1)
 
 	assert.True(t, canSee)
 }
@@ -35,12 +38,12 @@ func Test_canSeeHero_false(t *testing.T) {
 
 	e := newEnemy()
 
-	canSee := e.canSeeHero(heroLoc)
+	canSee := e.canSeeHero(heroLoc, 1)
 
 	assert.False(t, canSee)
 }
 
-const testSightDistnace int32 = 50
+const testSightDistance int32 = 200
 
 type directionCheckTest struct {
 	name               string
@@ -146,14 +149,106 @@ func Test_directionCheck(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := &Enemy{
-				sightDistnace: testSightDistnace,
-				direction:     tt.input,
-				x:             tt.x,
-				y:             tt.y,
-				world:         world.NewWorld(tt.areaW, tt.areaH, nil),
+				sightDistance: testSightDistance,
+				heading:       tt.input.Angle(),
+				pos:           vec.Vec{X: float64(tt.x), Y: float64(tt.y)},
+				world:         world.NewWorld(tt.areaW, tt.areaH, nil, 0, audio.Silent{}),
 			}
 			e.directionCheck()
-			assert.Equal(t, tt.expected, e.direction)
+			assert.Equal(t, tt.expected, direction.Of(e.heading))
 		})
 	}
 }
+
+func Test_Update_turns_to_noise(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Sentry, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+	e.facing, e.heading = direction.North.Angle(), direction.North.Angle()
+
+	w.MakeNoise(sdl.Point{X: 700, Y: 525}, 300)
+	e.Update()
+
+	assert.Equal(t, direction.East, direction.Of(e.heading))
+	assert.True(t, e.hearsNoise())
+}
+
+func Test_Update_investigates_noise(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Guard, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+
+	w.MakeNoise(sdl.Point{X: 625, Y: 525}, 300)
+	e.Update()
+	w.Update()
+
+	for i := 0; i < 200 && e.hearsNoise(); i++ {
+		e.Update()
+	}
+
+	assert.False(t, e.hearsNoise())
+	assert.InDelta(t, 625, e.center().X, 3)
+}
+
+func Test_Update_far_noise_is_not_heard(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 100, 100, Guard, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+
+	w.MakeNoise(sdl.Point{X: 900, Y: 900}, 300)
+	e.Update()
+
+	assert.False(t, e.hearsNoise())
+}
+
+func Test_Update_converges_on_alarm(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	near, err := testTrees(t).Spawn("enemy-0", 300, 300, Hunter, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+	far, err := testTrees(t).Spawn("enemy-1", 900, 900, Guard, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+
+	w.RaiseAlert(sdl.Point{X: 525, Y: 325})
+	for i := 0; i < 200; i++ {
+		near.Update()
+		far.Update()
+	}
+
+	assert.InDelta(t, 525, near.center().X, 10)
+	assert.InDelta(t, 325, near.center().Y, 10)
+	assert.Equal(t, sdl.Point{X: 925, Y: 925}, far.center())
+}
+
+func Test_Update_stunned_by_impact(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Patroller, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+
+	w.MakeImpact(sdl.Point{X: 600, Y: 525}, 150)
+	e.Update()
+	w.Update()
+	pos := e.pos
+	for i := 0; i < 50; i++ {
+		e.Update()
+	}
+
+	assert.True(t, e.isStunned())
+	assert.Equal(t, pos, e.pos)
+
+	for i := 0; i < stunTicks; i++ {
+		e.Update()
+	}
+	assert.False(t, e.isStunned())
+}
+
+func Test_TouchHazard(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Patroller, DefaultProps(), Route{}, w)
+	assert.NoError(t, err)
+
+	e.TouchHazard(hazard.NewPlate("plate", 500, 500, 30, 30, "", w))
+	assert.False(t, e.isStunned(), "plates don't hurt")
+
+	e.TouchHazard(hazard.NewFire("fire", 500, 500, 50, 50, 100, w))
+	assert.True(t, e.isStunned())
+}
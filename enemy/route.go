@@ -0,0 +1,51 @@
+package enemy
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// Waypoint is a point of a patrol Route.
+type Waypoint struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+	// Wait is a number of ticks an Enemy stays at the Waypoint and looks around, zero means no stop.
+	Wait int64 `json:"wait,omitempty"`
+}
+
+// Point returns location of the Waypoint.
+func (w Waypoint) Point() sdl.Point {
+	return sdl.Point{X: w.X, Y: w.Y}
+}
+
+// Route is an ordered list of waypoints, which is walked in a loop,
+// or back and forth if PingPong is set.
+type Route struct {
+	Waypoints []Waypoint `json:"waypoints"`
+	PingPong  bool       `json:"pingPong,omitempty"`
+}
+
+// next returns index of the Waypoint after the one at "i", when moving in the direction "step",
+// as well as the direction to keep moving in.
+func (r Route) next(i, step int) (int, int) {
+	n := len(r.Waypoints)
+	if n < 2 {
+		return 0, 1
+	}
+	if !r.PingPong {
+		return (i + 1) % n, 1
+	}
+	if i+step < 0 || i+step >= n {
+		step = -step
+	}
+	return i + step, step
+}
+
+// nearest returns index of the Waypoint, which is the closest to the point "p".
+func (r Route) nearest(p sdl.Point) int {
+	best, bestDist := 0, int64(-1)
+	for i, w := range r.Waypoints {
+		dx, dy := int64(w.X-p.X), int64(w.Y-p.Y)
+		if d := dx*dx + dy*dy; bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
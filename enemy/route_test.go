@@ -0,0 +1,79 @@
+package enemy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/vec"
+	"github.com/smeshkov/trovehero/world"
+)
+
+var testRoute = Route{Waypoints: []Waypoint{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}}}
+
+func Test_Route_next_loop(t *testing.T) {
+	var visited []int
+	i, step := 0, 1
+	for n := 0; n < 4; n++ {
+		i, step = testRoute.next(i, step)
+		visited = append(visited, i)
+	}
+
+	assert.Equal(t, []int{1, 2, 0, 1}, visited)
+}
+
+func Test_Route_next_ping_pong(t *testing.T) {
+	r := testRoute
+	r.PingPong = true
+
+	var visited []int
+	i, step := 0, 1
+	for n := 0; n < 5; n++ {
+		i, step = r.next(i, step)
+		visited = append(visited, i)
+	}
+
+	assert.Equal(t, []int{1, 2, 1, 0, 1}, visited)
+}
+
+func Test_Route_nearest(t *testing.T) {
+	assert.Equal(t, 2, testRoute.nearest(sdl.Point{X: 90, Y: 120}))
+	assert.Equal(t, 0, testRoute.nearest(sdl.Point{X: -50, Y: 10}))
+}
+
+func Test_Patrol_waits_at_waypoint(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	route := Route{Waypoints: []Waypoint{{X: 525, Y: 525, Wait: 50}, {X: 725, Y: 525}}}
+	e, err := testTrees(t).Spawn("enemy-0", 500, 500, Patroller, DefaultProps(), route, w)
+	assert.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		e.Update()
+	}
+
+	assert.Equal(t, sdl.Point{X: 525, Y: 525}, e.center())
+}
+
+func Test_Patrol_resumes_after_chase(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	route := Route{Waypoints: []Waypoint{{X: 125, Y: 125}, {X: 825, Y: 125}, {X: 825, Y: 825}}}
+	e, err := testTrees(t).Spawn("enemy-0", 100, 100, Patroller, DefaultProps(), route, w)
+	assert.NoError(t, err)
+
+	// chase drags Enemy far from the route
+	e.seesHero = true
+	e.pos = vec.Vec{X: 775, Y: 700}
+	e.Update()
+	e.seesHero = false
+
+	reached := false
+	for i := 0; i < 200 && !reached; i++ {
+		e.Update()
+		c := e.center()
+		reached = abs(c.X-825) <= 3 && abs(c.Y-825) <= 3
+	}
+
+	assert.True(t, reached)
+}
@@ -0,0 +1,218 @@
+package enemy
+
+import (
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/bt"
+	"github.com/smeshkov/trovehero/types/status"
+)
+
+// keys of the blackboard
+const (
+	// post is where Enemy has spawned
+	keyPost = "post"
+	// state of the patrol
+	keyNext      = "next"
+	keyStep      = "step"
+	keyWaitUntil = "waitUntil"
+	// chased is set when Enemy leaves its route to chase Hero
+	keyChased = "chased"
+)
+
+// Trees are behaviour trees of the archetypes.
+type Trees map[Archetype]bt.Spec
+
+// LoadTrees loads behaviour trees of all the archetypes from the assets, e.g. "ai/hunter.json".
+func LoadTrees(a *assets.Manager) (Trees, error) {
+	trees := make(Trees, len(archetypes))
+	for name := range archetypes {
+		data, err := a.Read(fmt.Sprintf("ai/%s.json", name))
+		if err != nil {
+			return nil, fmt.Errorf("could not read tree of %s: %w", name, err)
+		}
+		s, err := bt.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("could not load tree of %s: %w", name, err)
+		}
+		trees[name] = s
+	}
+	return trees, nil
+}
+
+// Tree is a Behaviour, which is driven by a behaviour tree.
+type Tree struct {
+	root  bt.Node
+	board *bt.Blackboard
+	trace bt.Trace
+	route Route
+
+	e    *Enemy
+	move bool
+}
+
+// NewTree builds a Tree by its Spec "s", "route" is followed by the "patrol" leaf.
+func NewTree(s bt.Spec, route Route) (*Tree, error) {
+	t := &Tree{board: bt.NewBlackboard(), route: route}
+	root, err := bt.Build(s, t.leaves())
+	if err != nil {
+		return nil, err
+	}
+	t.root = root
+	return t, nil
+}
+
+// Start ...
+func (t *Tree) Start(e *Enemy) {
+	t.e = e
+	t.board.Clear()
+	t.board.Set(keyPost, e.center())
+	t.resume()
+}
+
+// Update ...
+func (t *Tree) Update(e *Enemy) bool {
+	t.move = false
+	bt.Tick(t.root, t.board, &t.trace)
+	return t.move
+}
+
+// Trace returns nodes ticked during the last update.
+func (t *Tree) Trace() bt.Trace {
+	return bt.Trace{Steps: append([]bt.Step(nil), t.trace.Steps...)}
+}
+
+// leaves returns leaves, which trees of enemies are built of.
+func (t *Tree) leaves() bt.Leaves {
+	return bt.Leaves{
+		"seesHero":         bt.Condition(func(*bt.Context) bool { return t.e.seesHero }),
+		"remembersHero":    bt.Condition(func(*bt.Context) bool { return t.e.remembersHero() }),
+		"heardNoise":       bt.Condition(func(*bt.Context) bool { return t.e.hearsNoise() }),
+		"alarmed":          bt.Condition(t.alarmed),
+		"hasRoute":         bt.Condition(func(*bt.Context) bool { return len(t.route.Waypoints) > 0 }),
+		"chase":            t.chase,
+		"investigate":      t.investigate,
+		"investigateNoise": t.investigateNoise,
+		"converge":         t.converge,
+		"waitAtWaypoint":   t.waitAtWaypoint,
+		"patrol":           t.patrol,
+		"returnToPost":     t.returnToPost,
+		"lookAround":       t.lookAround,
+		"wander":           t.wander,
+	}
+}
+
+// chase moves Enemy in the direction Hero was seen.
+func (t *Tree) chase(ctx *bt.Context) status.Type {
+	ctx.Board.Set(keyChased, true)
+	t.move = true
+	return status.Running
+}
+
+// investigate moves Enemy to the place Hero was last seen at and makes it forget Hero there.
+func (t *Tree) investigate(ctx *bt.Context) status.Type {
+	if t.e.headTo(t.e.lastSeen) {
+		t.e.forgetHero()
+		return status.Success
+	}
+	t.move = true
+	return status.Running
+}
+
+// alarmed tells whether there is alarm close enough to Enemy to come and check.
+func (t *Tree) alarmed(ctx *bt.Context) bool {
+	_, ok := t.e.alarmed()
+	return ok
+}
+
+// converge moves Enemy to the last known position of Hero during alarm, it succeeds once Enemy is there.
+func (t *Tree) converge(ctx *bt.Context) status.Type {
+	p, ok := t.e.alarmed()
+	if !ok {
+		return status.Failure
+	}
+	ctx.Board.Set(keyChased, true)
+	if t.e.headTo(p) {
+		return status.Success
+	}
+	t.move = true
+	return status.Running
+}
+
+// investigateNoise moves Enemy to the place a noise was heard at and makes it forget the noise there.
+func (t *Tree) investigateNoise(ctx *bt.Context) status.Type {
+	ctx.Board.Set(keyChased, true)
+	if t.e.headTo(t.e.noise) {
+		t.e.forgetNoise()
+		return status.Success
+	}
+	t.move = true
+	return status.Running
+}
+
+// waitAtWaypoint makes Enemy look around at a waypoint, it fails if there is no need to wait.
+func (t *Tree) waitAtWaypoint(ctx *bt.Context) status.Type {
+	if t.e.time >= ctx.Board.Int64(keyWaitUntil) {
+		return status.Failure
+	}
+	t.e.lookAround()
+	return status.Running
+}
+
+// patrol moves Enemy to the next waypoint of the route, it succeeds on reaching it.
+// After a chase Enemy returns to the nearest waypoint.
+func (t *Tree) patrol(ctx *bt.Context) status.Type {
+	b := ctx.Board
+	if b.Bool(keyChased) {
+		t.resume()
+	}
+
+	next := b.Int(keyNext)
+	wp := t.route.Waypoints[next]
+	if !t.e.headTo(wp.Point()) {
+		t.move = true
+		return status.Running
+	}
+
+	next, step := t.route.next(next, b.Int(keyStep))
+	b.Set(keyNext, next)
+	b.Set(keyStep, step)
+	if wp.Wait > 0 {
+		b.Set(keyWaitUntil, t.e.time+wp.Wait)
+		t.e.turn()
+	}
+	return status.Success
+}
+
+// returnToPost moves Enemy to where it has spawned, it succeeds once Enemy is there.
+func (t *Tree) returnToPost(ctx *bt.Context) status.Type {
+	post, _ := ctx.Board.Get(keyPost)
+	if p, ok := post.(sdl.Point); ok && !t.e.headTo(p) {
+		t.move = true
+		return status.Running
+	}
+	return status.Success
+}
+
+// lookAround keeps Enemy in place turning from time to time.
+func (t *Tree) lookAround(ctx *bt.Context) status.Type {
+	t.e.lookAround()
+	return status.Running
+}
+
+// wander moves Enemy around, turning it away from the edges of the world.
+func (t *Tree) wander(ctx *bt.Context) status.Type {
+	t.e.directionCheck()
+	t.move = true
+	return status.Running
+}
+
+// resume heads Enemy to the nearest waypoint of the route.
+func (t *Tree) resume() {
+	t.board.Set(keyNext, t.route.nearest(t.e.center()))
+	t.board.Set(keyStep, 1)
+	t.board.Set(keyWaitUntil, int64(0))
+	t.board.Set(keyChased, false)
+}
@@ -0,0 +1,130 @@
+package exit
+
+import (
+	"sync"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+// Size is a side of the exit portal.
+const Size = 60
+
+// Exit is a portal, which finishes the level, once it is unlocked and Hero reaches it.
+type Exit struct {
+	mu sync.RWMutex
+
+	ID string
+
+	time int64
+
+	X, Y int32
+	W, H int32
+
+	world *world.World
+
+	open    bool
+	reached bool
+}
+
+// NewExit creates new instance of locked Exit.
+func NewExit(id string, x, y int32, w *world.World) *Exit {
+	return &Exit{
+		ID:    id,
+		X:     x,
+		Y:     y,
+		W:     Size,
+		H:     Size,
+		world: w,
+	}
+}
+
+// Update ...
+func (e *Exit) Update() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.time++
+}
+
+// Paint ...
+func (e *Exit) Paint(r *sdl.Renderer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	rect := &sdl.Rect{X: e.X, Y: e.Y, W: e.W, H: e.H}
+	if !e.open {
+		r.SetDrawColor(90, 0, 110, 255)
+		r.DrawRect(rect)
+		r.SetDrawColor(0, 0, 0, 255)
+		return nil
+	}
+
+	// open portal pulses
+	d := int32(e.time / 5 % 10)
+	r.SetDrawColor(200, 0, 230, 255)
+	r.FillRect(rect)
+	r.SetDrawColor(255, 150, 255, 255)
+	r.FillRect(&sdl.Rect{X: e.X + 10 + d, Y: e.Y + 10 + d, W: e.W - 20 - 2*d, H: e.H - 20 - 2*d})
+	r.SetDrawColor(0, 0, 0, 255)
+
+	return nil
+}
+
+// Restart places the Exit anew and locks it.
+func (e *Exit) Restart() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pos, err := e.world.Place(e.ID, kind.Exit, Size, Size)
+	if err != nil {
+		// no space left, Exit restarts where it is
+		pos = &sdl.Rect{X: e.X, Y: e.Y, W: e.W, H: e.H}
+		e.world.Register(e.ID, kind.Exit, pos)
+	}
+	e.X, e.Y = pos.X, pos.Y
+	e.time = 0
+	e.open, e.reached = false, false
+}
+
+// Destroy ...
+func (e *Exit) Destroy() {}
+
+// Bounds returns the area of the Exit.
+func (e *Exit) Bounds() *sdl.Rect {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return &sdl.Rect{X: e.X, Y: e.Y, W: e.W, H: e.H}
+}
+
+// Unlock opens the Exit.
+func (e *Exit) Unlock() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.open = true
+}
+
+// IsOpen tells whether the Exit has been unlocked.
+func (e *Exit) IsOpen() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.open
+}
+
+// Reach tells the Exit, that Hero has reached it, it takes effect only when the Exit is open.
+func (e *Exit) Reach() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.open {
+		e.reached = true
+	}
+}
+
+// IsReached tells whether Hero has reached the open Exit.
+func (e *Exit) IsReached() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.reached
+}
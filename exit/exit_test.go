@@ -0,0 +1,37 @@
+package exit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Reach_locked(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e := NewExit("exit", 100, 100, w)
+
+	e.Reach()
+	assert.False(t, e.IsReached(), "locked exit can't be reached")
+
+	e.Unlock()
+	e.Reach()
+	assert.True(t, e.IsReached())
+}
+
+func Test_Restart(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	e := NewExit("exit", 100, 100, w)
+	e.Unlock()
+	e.Reach()
+
+	e.Restart()
+
+	assert.False(t, e.IsOpen())
+	assert.False(t, e.IsReached())
+	ent, ok := w.Entity(e.ID)
+	assert.True(t, ok)
+	assert.Equal(t, *e.Bounds(), ent.Bounds)
+}
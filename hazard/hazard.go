@@ -0,0 +1,335 @@
+// Package hazard provides traps, which hurt the Hero and stun enemies.
+package hazard
+
+import (
+	"sync"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/hazard"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/vec"
+	"github.com/smeshkov/trovehero/world"
+)
+
+const (
+	// boulderSpeed and arrowSpeed are in pixels per tick.
+	boulderSpeed = 2
+	arrowSpeed   = 8
+	// plateCooldown is a number of ticks a plate needs to be ready again.
+	plateCooldown = 200
+	// DefaultPeriod is a period of spikes, fire and timed pits, which are given none.
+	DefaultPeriod = 200
+)
+
+// damages dealt by hazards, plates don't hurt.
+var damages = map[hazard.Type]int{
+	hazard.Spikes:  1,
+	hazard.Arrow:   1,
+	hazard.Boulder: 2,
+	hazard.Fire:    1,
+}
+
+// Hazard represents an arbitrary trap in the scene.
+type Hazard struct {
+	mu sync.RWMutex
+
+	ID   string
+	Type hazard.Type
+
+	time int64
+
+	X, Y int32
+	W, H int32
+
+	// path of a boulder or an arrow, positions of its top left corner, which it goes from the first to the last one
+	path []sdl.Point
+	pos  vec.Vec
+	next int
+	// flying is true, while an arrow is in the air
+	flying bool
+
+	// period of spikes and fire, they are on and off for a period in turn
+	period int64
+
+	// Trigger is an ID of the arrow fired by a plate, plate raises the alarm, if it is empty
+	Trigger string
+	targets []*Hazard
+	readyAt int64
+
+	world *world.World
+}
+
+func newHazard(id string, t hazard.Type, x, y, width, height int32, w *world.World) *Hazard {
+	hz := &Hazard{
+		ID:    id,
+		Type:  t,
+		W:     width,
+		H:     height,
+		world: w,
+	}
+	hz.reset(x, y)
+	return hz
+}
+
+// NewSpikes creates new instance of spikes, which come out every other "period" ticks.
+func NewSpikes(id string, x, y, width, height int32, period int64, w *world.World) *Hazard {
+	hz := newHazard(id, hazard.Spikes, x, y, width, height, w)
+	hz.setPeriod(period)
+	return hz
+}
+
+// NewFire creates new instance of a fire tile, which burns every other "period" ticks.
+func NewFire(id string, x, y, width, height int32, period int64, w *world.World) *Hazard {
+	hz := newHazard(id, hazard.Fire, x, y, width, height, w)
+	hz.setPeriod(period)
+	return hz
+}
+
+// NewPlate creates new instance of a pressure plate, which fires the arrow with ID "trigger"
+// or raises the alarm, if "trigger" is empty.
+func NewPlate(id string, x, y, width, height int32, trigger string, w *world.World) *Hazard {
+	hz := newHazard(id, hazard.Plate, x, y, width, height, w)
+	hz.Trigger = trigger
+	return hz
+}
+
+// NewArrow creates new instance of an arrow, which flies along the given "path", once it is fired.
+func NewArrow(id string, width, height int32, path []sdl.Point, w *world.World) *Hazard {
+	hz := newHazard(id, hazard.Arrow, path[0].X, path[0].Y, width, height, w)
+	hz.path = append([]sdl.Point(nil), path...)
+	return hz
+}
+
+// NewBoulder creates new instance of a boulder, which rolls along the given "path" over and over again.
+func NewBoulder(id string, width, height int32, path []sdl.Point, w *world.World) *Hazard {
+	hz := newHazard(id, hazard.Boulder, path[0].X, path[0].Y, width, height, w)
+	hz.path = append([]sdl.Point(nil), path...)
+	return hz
+}
+
+// setPeriod sets the "period" of spikes and fire, the default one, if it isn't positive.
+func (hz *Hazard) setPeriod(period int64) {
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	hz.period = period
+}
+
+// Link connects plates with arrows they fire.
+func Link(hazards []*Hazard) {
+	byID := make(map[string]*Hazard, len(hazards))
+	for _, hz := range hazards {
+		byID[hz.ID] = hz
+	}
+	for _, hz := range hazards {
+		if t, ok := byID[hz.Trigger]; ok && hz.Type == hazard.Plate {
+			hz.targets = append(hz.targets, t)
+		}
+	}
+}
+
+// reset puts the Hazard at the given position in its initial state.
+func (hz *Hazard) reset(x, y int32) {
+	hz.time = 0
+	hz.X, hz.Y = x, y
+	hz.pos = vec.Of(sdl.Point{X: x, Y: y})
+	hz.next = 1
+	hz.flying = false
+	hz.readyAt = 0
+}
+
+// Bounds returns the current area of the Hazard.
+func (hz *Hazard) Bounds() *sdl.Rect {
+	hz.mu.RLock()
+	defer hz.mu.RUnlock()
+	return &sdl.Rect{X: hz.X, Y: hz.Y, W: hz.W, H: hz.H}
+}
+
+// Damage returns damage dealt by the Hazard, if it is active.
+func (hz *Hazard) Damage() int {
+	return damages[hz.Type]
+}
+
+// IsActive tells whether the Hazard hurts right now.
+func (hz *Hazard) IsActive() bool {
+	hz.mu.RLock()
+	defer hz.mu.RUnlock()
+	return hz.isActive()
+}
+
+func (hz *Hazard) isActive() bool {
+	switch hz.Type {
+	case hazard.Spikes:
+		// spikes are hidden at first
+		return hz.time/hz.period%2 == 1
+	case hazard.Fire:
+		return hz.time/hz.period%2 == 0
+	case hazard.Arrow:
+		return hz.flying
+	case hazard.Boulder:
+		return true
+	}
+	return false
+}
+
+// Press tells the plate, that it is stepped on, it either fires its arrows or raises the alarm.
+func (hz *Hazard) Press() {
+	hz.mu.Lock()
+	defer hz.mu.Unlock()
+
+	if hz.Type != hazard.Plate || hz.time < hz.readyAt {
+		return
+	}
+	hz.readyAt = hz.time + plateCooldown
+
+	if len(hz.targets) == 0 {
+		hz.world.RaiseAlert(sdl.Point{X: hz.X + hz.W/2, Y: hz.Y + hz.H/2})
+		return
+	}
+	for _, t := range hz.targets {
+		t.Fire()
+	}
+}
+
+// Fire launches the arrow from the start of its path, unless it is already in the air.
+func (hz *Hazard) Fire() {
+	hz.mu.Lock()
+	defer hz.mu.Unlock()
+
+	if hz.Type != hazard.Arrow || hz.flying {
+		return
+	}
+	hz.flying = true
+}
+
+// Update ...
+func (hz *Hazard) Update() {
+	hz.mu.Lock()
+	defer hz.mu.Unlock()
+
+	hz.time++
+
+	switch hz.Type {
+	case hazard.Boulder:
+		hz.move(boulderSpeed)
+	case hazard.Arrow:
+		if hz.flying {
+			hz.move(arrowSpeed)
+		}
+	}
+}
+
+// move moves the Hazard towards the next point of its path, at the end of the path it is back to the start,
+// arrow lands there.
+func (hz *Hazard) move(speed float64) {
+	if len(hz.path) < 2 {
+		return
+	}
+
+	if hz.next >= len(hz.path) {
+		hz.pos = vec.Of(hz.path[0])
+		hz.next = 1
+		hz.flying = false
+	} else if to := vec.Of(hz.path[hz.next]).Sub(hz.pos); to.Len() > speed {
+		hz.pos = hz.pos.Add(to.Norm().Scale(speed))
+	} else {
+		hz.pos = vec.Of(hz.path[hz.next])
+		hz.next++
+	}
+
+	p := hz.pos.Point()
+	hz.X, hz.Y = p.X, p.Y
+	hz.world.Move(hz.ID, &sdl.Rect{X: hz.X, Y: hz.Y, W: hz.W, H: hz.H}, 0)
+}
+
+// Paint ...
+func (hz *Hazard) Paint(r *sdl.Renderer) error {
+	hz.mu.RLock()
+	defer hz.mu.RUnlock()
+
+	rect := &sdl.Rect{X: hz.X, Y: hz.Y, W: hz.W, H: hz.H}
+	switch hz.Type {
+	case hazard.Spikes:
+		if hz.isActive() {
+			r.SetDrawColor(200, 200, 200, 255)
+			r.FillRect(rect)
+		} else {
+			r.SetDrawColor(120, 120, 120, 255)
+			r.DrawRect(rect)
+		}
+	case hazard.Fire:
+		if hz.isActive() {
+			// flames flicker
+			if hz.time/4%2 == 0 {
+				r.SetDrawColor(255, 120, 0, 255)
+			} else {
+				r.SetDrawColor(230, 40, 0, 255)
+			}
+			r.FillRect(rect)
+		} else {
+			r.SetDrawColor(120, 30, 0, 255)
+			r.DrawRect(rect)
+		}
+	case hazard.Plate:
+		if hz.time < hz.readyAt {
+			r.SetDrawColor(90, 90, 90, 255)
+		} else {
+			r.SetDrawColor(150, 140, 120, 255)
+		}
+		r.FillRect(rect)
+	case hazard.Arrow:
+		// arrows are seen only in the air
+		if hz.flying {
+			r.SetDrawColor(230, 230, 230, 255)
+			r.FillRect(rect)
+		}
+	case hazard.Boulder:
+		r.SetDrawColor(100, 80, 70, 255)
+		r.FillRect(rect)
+	}
+	r.SetDrawColor(0, 0, 0, 255)
+
+	return nil
+}
+
+// area returns an area covered by the Hazard along its whole path.
+func (hz *Hazard) area() sdl.Rect {
+	a := sdl.Rect{X: hz.X, Y: hz.Y, W: hz.W, H: hz.H}
+	for _, p := range hz.path {
+		r := sdl.Rect{X: p.X, Y: p.Y, W: hz.W, H: hz.H}
+		a = a.Union(&r)
+	}
+	return a
+}
+
+// Restart places the Hazard anew with its path and puts it in the initial state.
+func (hz *Hazard) Restart() {
+	hz.mu.Lock()
+	defer hz.mu.Unlock()
+
+	area := hz.area()
+	pos, err := hz.world.Place(hz.ID, kind.Hazard, area.W, area.H)
+	if err != nil {
+		// no space left, Hazard restarts where it is
+		hz.world.Register(hz.ID, kind.Hazard, &sdl.Rect{X: hz.X, Y: hz.Y, W: hz.W, H: hz.H})
+		hz.reset(hz.X, hz.Y)
+		return
+	}
+
+	// shift the Hazard with its path to the new place
+	dx, dy := pos.X-area.X, pos.Y-area.Y
+	for i := range hz.path {
+		hz.path[i].X += dx
+		hz.path[i].Y += dy
+	}
+	x, y := hz.X+dx, hz.Y+dy
+	if len(hz.path) > 0 {
+		x, y = hz.path[0].X, hz.path[0].Y
+	}
+	hz.reset(x, y)
+	hz.world.Move(hz.ID, &sdl.Rect{X: hz.X, Y: hz.Y, W: hz.W, H: hz.H}, 0)
+}
+
+// Destroy ...
+func (hz *Hazard) Destroy() {}
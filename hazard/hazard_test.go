@@ -0,0 +1,94 @@
+package hazard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/alert"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_IsActive_spikes(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	hz := NewSpikes("spikes", 100, 100, 50, 50, 10, w)
+
+	assert.False(t, hz.IsActive(), "hidden at first")
+	for i := 0; i < 10; i++ {
+		hz.Update()
+	}
+	assert.True(t, hz.IsActive())
+	for i := 0; i < 10; i++ {
+		hz.Update()
+	}
+	assert.False(t, hz.IsActive())
+}
+
+func Test_IsActive_no_period(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	spikes := NewSpikes("spikes", 100, 100, 50, 50, 0, w)
+	fire := NewFire("fire", 200, 100, 50, 50, -1, w)
+
+	assert.False(t, spikes.IsActive())
+	assert.True(t, fire.IsActive())
+	for i := 0; i < DefaultPeriod; i++ {
+		spikes.Update()
+		fire.Update()
+	}
+	assert.True(t, spikes.IsActive(), "default period is used")
+	assert.False(t, fire.IsActive())
+}
+
+func Test_Press_raises_alarm(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	hz := NewPlate("plate", 100, 100, 30, 30, "", w)
+
+	hz.Press()
+
+	assert.Equal(t, alert.Alarm, w.Alert().Level)
+	assert.Equal(t, sdl.Point{X: 115, Y: 115}, w.Alert().LastKnown)
+	assert.False(t, hz.IsActive(), "plate doesn't hurt")
+}
+
+func Test_Press_fires_arrow(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	plate := NewPlate("plate", 100, 100, 30, 30, "arrow", w)
+	arrow := NewArrow("arrow", 10, 10, []sdl.Point{{X: 0, Y: 110}, {X: 400, Y: 110}}, w)
+	w.Register(arrow.ID, kind.Hazard, arrow.Bounds())
+	Link([]*Hazard{plate, arrow})
+
+	assert.False(t, arrow.IsActive())
+	plate.Press()
+	assert.True(t, arrow.IsActive())
+	assert.Equal(t, alert.Calm, w.Alert().Level)
+
+	for i := 0; i < 10; i++ {
+		arrow.Update()
+	}
+	assert.Equal(t, int32(80), arrow.Bounds().X)
+	e, _ := w.Entity(arrow.ID)
+	assert.Equal(t, int32(80), e.Bounds.X)
+
+	for i := 0; i < 50; i++ {
+		arrow.Update()
+	}
+	assert.False(t, arrow.IsActive(), "arrow lands")
+	assert.Equal(t, int32(0), arrow.Bounds().X)
+}
+
+func Test_Update_boulder(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	hz := NewBoulder("boulder", 40, 40, []sdl.Point{{X: 100, Y: 100}, {X: 100, Y: 200}}, w)
+
+	for i := 0; i < 25; i++ {
+		hz.Update()
+	}
+	assert.Equal(t, int32(150), hz.Bounds().Y)
+	for i := 0; i < 26; i++ {
+		hz.Update()
+	}
+	assert.Equal(t, int32(100), hz.Bounds().Y, "rolls again from the start")
+}
@@ -0,0 +1,158 @@
+package hero
+
+import (
+	"github.com/smeshkov/trovehero/types/ability"
+	"github.com/smeshkov/trovehero/types/item"
+)
+
+const (
+	maxStamina = 100
+	// staminaRegen is stamina restored per tick, while Hero does not sneak
+	staminaRegen = 0.2
+
+	// dashSpeed is a speed of a dash, Hero can't change direction for dashTicks
+	dashSpeed = 10
+	dashTicks = 15
+
+	// poundSpeed is a speed Hero falls with when pounding the ground from the air
+	poundSpeed = 6
+	// PoundRadius is a distance from Hero, within which ground-pound stuns enemies
+	PoundRadius = 150
+	poundNoise  = 300
+
+	// sneakVisibility scales the distance enemies can see a sneaking Hero from
+	sneakVisibility = 0.6
+)
+
+// cost is a cost of an ability.
+type cost struct {
+	// stamina spent on use, sneaking spends it on every tick
+	stamina float64
+	// cooldown is a number of ticks before ability can be used again
+	cooldown int64
+}
+
+var costs = map[ability.Type]cost{
+	ability.Dash:        {stamina: 30, cooldown: 100},
+	ability.Sneak:       {stamina: 0.15},
+	ability.GroundPound: {stamina: 40, cooldown: 200},
+}
+
+// Status is a state of the Hero's abilities to show on HUD.
+type Status struct {
+	// Stamina left from 0 to 1
+	Stamina float64
+	// Ready tells which abilities can be used now
+	Ready map[ability.Type]bool
+	// Sneaking tells whether Hero sneaks
+	Sneaking bool
+}
+
+// Abilities returns Status of the Hero's abilities.
+func (h *Hero) Abilities() Status {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	s := Status{
+		Stamina:  h.stamina / maxStamina,
+		Ready:    make(map[ability.Type]bool, len(costs)),
+		Sneaking: h.sneaking,
+	}
+	for a := range costs {
+		s.Ready[a] = h.ready(a)
+	}
+	return s
+}
+
+// Visibility scales the distance enemies can see Hero from, invisible Hero can't be seen at all.
+func (h *Hero) Visibility() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.active(item.Invisibility) {
+		return 0
+	}
+	if h.sneaking {
+		return sneakVisibility
+	}
+	return 1
+}
+
+// ready tells whether ability "a" is cooled down and Hero has enough stamina for it.
+func (h *Hero) ready(a ability.Type) bool {
+	return h.time >= h.readyAt[a] && h.stamina >= costs[a].stamina
+}
+
+// use spends stamina and starts cooldown of ability "a", it tells whether ability can be used.
+func (h *Hero) use(a ability.Type) bool {
+	if !h.ready(a) {
+		return false
+	}
+	c := costs[a]
+	h.stamina -= c.stamina
+	h.readyAt[a] = h.time + c.cooldown
+	return true
+}
+
+// dash speeds Hero up in the direction of movement.
+func (h *Hero) dash() {
+	if h.altitude != 0 || h.vel.IsZero() || !h.use(ability.Dash) {
+		return
+	}
+	h.vel = h.vel.Norm().Scale(dashSpeed)
+	h.lockedUntil = h.time + dashTicks
+	h.dashing = true
+}
+
+// endDash slows Hero down to its running speed, once the dash is over, so that it doesn't slide on and on.
+func (h *Hero) endDash() {
+	if !h.dashing || h.isLocked() {
+		return
+	}
+	h.dashing = false
+	h.vel = h.vel.Limit(h.moveSpeed())
+}
+
+// isLocked tells whether Hero dashes or is knocked back and can't change direction.
+func (h *Hero) isLocked() bool {
+	return h.time < h.lockedUntil
+}
+
+// groundPound slams the ground right away or, if Hero is in the air, on landing.
+func (h *Hero) groundPound() {
+	if h.crashingDepth != 0 || h.pounding || !h.use(ability.GroundPound) {
+		return
+	}
+	if h.altitude == 0 {
+		h.impact()
+		return
+	}
+	h.pounding = true
+	h.altSpeed = -poundSpeed
+}
+
+// impact stuns enemies around Hero, it is loud even for a sneaking Hero.
+func (h *Hero) impact() {
+	h.pounding = false
+	h.world.MakeImpact(h.center(), PoundRadius)
+	h.world.MakeNoise(h.center(), poundNoise)
+}
+
+// handleStamina spends stamina on sneaking or restores it otherwise, Hero stops sneaking when exhausted.
+func (h *Hero) handleStamina() {
+	if !h.sneaking {
+		h.stamina = min(maxStamina, h.stamina+staminaRegen)
+		return
+	}
+	h.stamina -= costs[ability.Sneak].stamina
+	if h.stamina <= 0 {
+		h.stamina = 0
+		h.sneaking = false
+	}
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
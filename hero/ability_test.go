@@ -0,0 +1,113 @@
+package hero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/ability"
+	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Do_dash(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 100, 500, w)
+
+	h.Do(command.GoEast)
+	h.Do(command.Dash)
+	h.Do(command.GoEast)
+
+	assert.InDelta(t, dashSpeed, h.vel.Len(), 1e-6, "direction is locked while dashing")
+	assert.False(t, h.Abilities().Ready[ability.Dash])
+	assert.InDelta(t, 1-costs[ability.Dash].stamina/maxStamina, h.Abilities().Stamina, 1e-6)
+}
+
+func Test_Do_dash_cooldown(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 100, 500, w)
+
+	h.Do(command.GoEast)
+	h.Do(command.Dash)
+	for i := int64(0); i < costs[ability.Dash].cooldown; i++ {
+		h.Update()
+	}
+	h.Do(command.GoEast)
+	h.Do(command.Dash)
+
+	assert.InDelta(t, dashSpeed, h.vel.Len(), 1e-6)
+}
+
+func Test_Do_dash_distance(t *testing.T) {
+	w := world.NewWorld(2000, 1000, nil, 0, audio.Silent{})
+	run := NewHero("run", 100, 100, w)
+	dash := NewHero("dash", 100, 500, w)
+
+	run.Do(command.GoEast)
+	dash.Do(command.GoEast)
+	dash.Do(command.Dash)
+	for i := 0; i < 300; i++ {
+		run.Update()
+		dash.Update()
+	}
+
+	assert.True(t, dash.vel.IsZero(), "Hero stops after a dash")
+	extra := dash.pos.X - run.pos.X
+	assert.Greater(t, extra, float64(dashTicks*(dashSpeed-run.maxMoveSpeed)/2), "dash takes Hero further")
+	assert.LessOrEqual(t, extra, float64(dashTicks*dashSpeed), "Hero doesn't slide on after a dash")
+}
+
+func Test_Do_dash_standing_still(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 100, 500, w)
+
+	h.Do(command.Dash)
+
+	assert.True(t, h.vel.IsZero())
+	assert.True(t, h.Abilities().Ready[ability.Dash])
+}
+
+func Test_Update_sneak_exhausts_stamina(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 100, 500, w)
+
+	h.Sneak(true)
+	assert.Equal(t, sneakVisibility, h.Visibility())
+	for i := 0; i < 1000; i++ {
+		h.Update()
+	}
+
+	assert.False(t, h.Abilities().Sneaking)
+	assert.Equal(t, 1.0, h.Visibility())
+}
+
+func Test_Do_ground_pound(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Do(command.GroundPound)
+
+	assert.Len(t, w.ImpactsAt(sdl.Point{X: 525 + PoundRadius - 10, Y: 525}), 1)
+	assert.Empty(t, w.ImpactsAt(sdl.Point{X: 525 + PoundRadius + 10, Y: 525}))
+}
+
+func Test_Do_ground_pound_from_the_air(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Do(command.Jump)
+	for i := 0; i < 10; i++ {
+		h.Update()
+	}
+	h.Do(command.GroundPound)
+	assert.Empty(t, w.ImpactsAt(sdl.Point{X: 525, Y: 525}))
+
+	landed := false
+	for i := 0; i < 50 && !landed; i++ {
+		h.Update()
+		landed = len(w.ImpactsAt(sdl.Point{X: 525, Y: 525})) > 0
+	}
+	assert.True(t, landed)
+}
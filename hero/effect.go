@@ -0,0 +1,124 @@
+package hero
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/vec"
+)
+
+const (
+	// speedBoost and jumpBoost multiply speeds of a boosted Hero
+	speedBoost = 1.5
+	jumpBoost  = 1.5
+	// maxLives is the largest number of extra lives Hero can have
+	maxLives = 3
+	// maxStack limits how many durations of an effect can be stacked
+	maxStack = 3
+)
+
+// stacking tells what picking up an item does to its active effect.
+type stacking byte
+
+const (
+	// extend adds duration of the item to the remaining one
+	extend stacking = iota
+	// refresh starts the effect anew
+	refresh
+	// ignore leaves the item for later
+	ignore
+)
+
+// effect of an item, which lasts for a while.
+type effect struct {
+	duration int64
+	stack    stacking
+}
+
+var effects = map[item.Type]effect{
+	item.Speed:        {duration: 500, stack: extend},
+	item.Invisibility: {duration: 300, stack: refresh},
+	item.Shield:       {duration: 1000, stack: ignore},
+	item.Jump:         {duration: 500, stack: extend},
+}
+
+// Effects returns a number of ticks left of every active effect of the Hero.
+func (h *Hero) Effects() map[item.Type]int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	left := make(map[item.Type]int64, len(h.activeUntil))
+	for it := range h.activeUntil {
+		if h.active(it) {
+			left[it] = h.activeUntil[it] - h.time
+		}
+	}
+	return left
+}
+
+// Lives returns a number of extra lives of the Hero.
+func (h *Hero) Lives() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.lives
+}
+
+// Deaths returns a number of lives the Hero has lost through the whole game.
+func (h *Hero) Deaths() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.deaths
+}
+
+// active tells whether an effect of the item "it" is active.
+func (h *Hero) active(it item.Type) bool {
+	return h.time < h.activeUntil[it]
+}
+
+// apply applies effect of the item "it" by its stacking rule and tells whether the item was used.
+func (h *Hero) apply(it item.Type) bool {
+	e, ok := effects[it]
+	if !ok {
+		return false
+	}
+	if !h.active(it) {
+		h.activeUntil[it] = h.time + e.duration
+		return true
+	}
+
+	switch e.stack {
+	case extend:
+		h.activeUntil[it] += e.duration
+		if limit := h.time + maxStack*e.duration; h.activeUntil[it] > limit {
+			h.activeUntil[it] = limit
+		}
+	case refresh:
+		h.activeUntil[it] = h.time + e.duration
+	case ignore:
+		return false
+	}
+	return true
+}
+
+// loseLife saves Hero with an extra life at a new place or lets Hero die.
+func (h *Hero) loseLife() {
+	h.countDeath()
+	if h.lives == 0 || h.dead {
+		h.die()
+		return
+	}
+	pos, err := h.world.Place(h.ID, kind.Hero, heroW, heroH)
+	if err != nil {
+		h.die()
+		return
+	}
+
+	h.lives--
+	h.pos = vec.Of(sdl.Point{X: pos.X, Y: pos.Y})
+	h.vel = vec.Vec{}
+	h.altitude, h.altSpeed, h.crashingDepth = 0, 0, 0
+	h.pounding = false
+	h.health = h.maxHealth
+	h.invulnerableUntil = h.time + invulnerableTicks
+}
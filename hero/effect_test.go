@@ -0,0 +1,121 @@
+package hero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/pickup"
+	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+// pick makes Hero "h" to pick up an item "it" of the given "value" and tells whether it was collected.
+func pick(h *Hero, it item.Type, value int) bool {
+	p := pickup.NewPickup("pickup-0", 510, 510, it, value, h.world)
+	h.TouchPickup(p)
+	return p.IsCollected()
+}
+
+func Test_TouchPickup_gem(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	assert.True(t, pick(h, item.Gem, 5))
+	assert.Equal(t, 5, w.GetScore())
+}
+
+func Test_TouchPickup_speed_extends(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	d := effects[item.Speed].duration
+
+	assert.True(t, pick(h, item.Speed, 0))
+	assert.True(t, pick(h, item.Speed, 0))
+	assert.Equal(t, 2*d, h.Effects()[item.Speed])
+	for i := 0; i < 5; i++ {
+		pick(h, item.Speed, 0)
+	}
+	assert.Equal(t, maxStack*d, h.Effects()[item.Speed], "stacking is limited")
+
+	h.Do(command.GoEast)
+	assert.InDelta(t, float64(h.maxMoveSpeed)*speedBoost, h.vel.Len(), 1e-6)
+}
+
+func Test_TouchPickup_invisibility(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	pick(h, item.Invisibility, 0)
+	assert.Equal(t, 0.0, h.Visibility())
+
+	for i := int64(0); i < effects[item.Invisibility].duration; i++ {
+		h.Update()
+	}
+	assert.Equal(t, 1.0, h.Visibility())
+	assert.Empty(t, h.Effects())
+}
+
+func Test_TouchPickup_shield(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	assert.True(t, pick(h, item.Shield, 0))
+	assert.False(t, pick(h, item.Shield, 0), "shields don't stack")
+
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+	assert.False(t, h.IsDead(), "shield absorbs even a fatal hit")
+	assert.NotContains(t, h.Effects(), item.Shield)
+}
+
+func Test_TouchPickup_life(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	w.Register(h.ID, kind.Hero, h.getFootprint())
+
+	assert.True(t, pick(h, item.Life, 0))
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+
+	assert.False(t, h.IsDead())
+	assert.Equal(t, 0, h.Lives())
+
+	for i := 0; i < invulnerableTicks; i++ {
+		h.Update()
+	}
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+	assert.True(t, h.IsDead())
+}
+
+func Test_TouchPickup_jump(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	pick(h, item.Jump, 0)
+	h.Do(command.Jump)
+
+	assert.Equal(t, h.maxJumpSpeed*jumpBoost, h.altSpeed)
+}
+
+func Test_Respawn_keeps_lives_and_effects(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	assert.True(t, pick(h, item.Life, 0))
+	assert.True(t, pick(h, item.Speed, 0))
+	for i := 0; i < 10; i++ {
+		h.Update()
+	}
+	left := h.Effects()[item.Speed]
+
+	h.Respawn(100, 100)
+	assert.Equal(t, 1, h.Lives(), "extra life survives the next level")
+	assert.Equal(t, left, h.Effects()[item.Speed])
+
+	h.Reset()
+	assert.Equal(t, 0, h.Lives(), "new game starts without extra lives")
+	assert.Empty(t, h.Effects())
+}
@@ -0,0 +1,118 @@
+package hero
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/hazard"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/vec"
+)
+
+const (
+	// knockbackSpeed is a speed Hero is pushed away with, when hurt
+	knockbackSpeed = 8
+	// knockbackTicks is a number of ticks Hero can't control its movement after being hurt
+	knockbackTicks = 20
+	// invulnerableTicks is a number of ticks Hero can't be hurt again
+	invulnerableTicks = 100
+	// healthPickup is health restored by a health pickup
+	healthPickup = 1
+	// pitDamage is dealt by a shallow pit
+	pitDamage = 1
+)
+
+// SetMaxHealth sets maximum health of the Hero and heals it fully,
+// Hero dies from the first hit, if "n" is zero.
+func (h *Hero) SetMaxHealth(n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.maxHealth = n
+	h.health = n
+}
+
+// Health returns current and maximum health of the Hero.
+func (h *Hero) Health() (int, int) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.health, h.maxHealth
+}
+
+// Hurt deals "damage" to the Hero from the point "from" and knocks Hero back.
+func (h *Hero) Hurt(damage int, from sdl.Point) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hurt(damage, from)
+}
+
+func (h *Hero) hurt(damage int, from sdl.Point) {
+	if h.dead || h.isInvulnerable() {
+		return
+	}
+	// shield absorbs the hit
+	if h.active(item.Shield) {
+		delete(h.activeUntil, item.Shield)
+		h.knockback(from)
+		return
+	}
+	// classic mode, every hit is fatal
+	if h.maxHealth <= 0 {
+		h.loseLife()
+		return
+	}
+
+	h.health -= damage
+	if h.health <= 0 {
+		h.health = 0
+		h.loseLife()
+		return
+	}
+	h.knockback(from)
+}
+
+// TouchHazard checks collision with Hazard, Hero jumps over hazards on the ground and presses plates.
+func (h *Hero) TouchHazard(hz *hazard.Hazard) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.altitude > 0 && hz.Type.IsGround() { // above in the air
+		return
+	}
+	r, m, b := h.getFootprint(), h.world.Physics.CollisionMargin, hz.Bounds()
+	if b.X > r.X+r.W-m || b.X+b.W-m < r.X || b.Y > r.Y+r.H-m || b.Y+b.H-m < r.Y {
+		return
+	}
+
+	hz.Press()
+	if hz.IsActive() {
+		h.hurt(hz.Damage(), sdl.Point{X: b.X + b.W/2, Y: b.Y + b.H/2})
+	}
+}
+
+// knockback pushes Hero away from the point "from" and makes it invulnerable for a while.
+func (h *Hero) knockback(from sdl.Point) {
+	away := h.pos.Add(vec.Vec{X: float64(h.w) / 2, Y: float64(h.h) / 2}).Sub(vec.Of(from))
+	if away.IsZero() {
+		// pushed back against the direction of movement
+		away = h.vel.Scale(-1)
+	}
+	h.vel = away.Norm().Scale(knockbackSpeed)
+	h.lockedUntil = h.time + knockbackTicks
+	h.invulnerableUntil = h.time + invulnerableTicks
+}
+
+// heal restores health of the Hero and tells whether Hero needed it.
+func (h *Hero) heal(n int) bool {
+	if h.maxHealth <= 0 || h.health >= h.maxHealth {
+		return false
+	}
+	h.health += n
+	if h.health > h.maxHealth {
+		h.health = h.maxHealth
+	}
+	return true
+}
+
+// isInvulnerable tells whether Hero recovers from a hit and can't be hurt.
+func (h *Hero) isInvulnerable() bool {
+	return h.time < h.invulnerableUntil
+}
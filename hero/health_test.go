@@ -0,0 +1,122 @@
+package hero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/hazard"
+	"github.com/smeshkov/trovehero/pickup"
+	"github.com/smeshkov/trovehero/pit"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Hurt_classic(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+
+	assert.True(t, h.IsDead())
+}
+
+func Test_Hurt_knocks_back(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	h.SetMaxHealth(3)
+
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+
+	health, max := h.Health()
+	assert.Equal(t, 2, health, "Hero is invulnerable right after a hit")
+	assert.Equal(t, 3, max)
+	assert.InDelta(t, knockbackSpeed, h.vel.X, 1e-6)
+	assert.False(t, h.IsDead())
+
+	for i := 0; i < invulnerableTicks; i++ {
+		h.Update()
+	}
+	h.Hurt(2, sdl.Point{X: 450, Y: 525})
+	assert.True(t, h.IsDead())
+}
+
+func Test_TouchPit_shallow(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	h.SetMaxHealth(3)
+
+	h.TouchPit(pit.NewPit("pit-0", 520, 450, 100, 150, pit.ShallowDepth-1, w))
+
+	health, _ := h.Health()
+	assert.Equal(t, 2, health)
+	assert.Equal(t, int8(0), h.crashingDepth)
+	assert.True(t, h.vel.X < 0, "Hero is pushed out of the pit")
+}
+
+func Test_TouchPit_deep(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	h.SetMaxHealth(3)
+
+	h.TouchPit(pit.NewPit("pit-0", 520, 450, 100, 150, 100, w))
+
+	assert.Equal(t, int8(100), h.crashingDepth)
+}
+
+func Test_TouchPickup_health(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	h.SetMaxHealth(3)
+	p := pickup.NewPickup("pickup-0", 510, 510, item.Health, 0, w)
+
+	h.TouchPickup(p)
+	assert.False(t, p.IsCollected(), "healthy Hero leaves health for later")
+
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+	h.TouchPickup(p)
+	assert.True(t, p.IsCollected())
+	health, _ := h.Health()
+	assert.Equal(t, 3, health)
+}
+
+func Test_TouchHazard(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	h.SetMaxHealth(3)
+	spikes := hazard.NewSpikes("spikes", 500, 500, 50, 50, 10, w)
+
+	h.TouchHazard(spikes)
+	health, _ := h.Health()
+	assert.Equal(t, 3, health, "spikes are hidden")
+
+	for !spikes.IsActive() {
+		spikes.Update()
+	}
+	h.altitude = 10
+	h.TouchHazard(spikes)
+	health, _ = h.Health()
+	assert.Equal(t, 3, health, "Hero jumps over spikes")
+
+	h.altitude = 0
+	h.TouchHazard(spikes)
+	health, _ = h.Health()
+	assert.Equal(t, 2, health)
+}
+
+func Test_Deaths(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	h.lives = 1
+
+	h.Hurt(1, sdl.Point{X: 450, Y: 525})
+	h.Respawn(500, 500)
+	h.Die()
+	h.Die()
+
+	assert.Equal(t, 2, h.Deaths(), "deaths survive respawn")
+	assert.Len(t, w.ScoreLog(), 2, "every death is penalized")
+}
@@ -6,21 +6,33 @@ import (
 
 	"github.com/veandco/go-sdl2/sdl"
 
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/exit"
+	"github.com/smeshkov/trovehero/pickup"
 	"github.com/smeshkov/trovehero/pit"
 	"github.com/smeshkov/trovehero/trove"
+	"github.com/smeshkov/trovehero/types/ability"
 	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/score"
+	"github.com/smeshkov/trovehero/vec"
 	"github.com/smeshkov/trovehero/world"
 )
 
 const (
-	gravity         = 0.1
-	friction        = 0.08
-	airFriction     = 0.1
-	altitudeMargin  = 35
-	collisionMargin = 10
-
 	heroW = 50
 	heroH = 50
+
+	// speedEpsilon absorbs rounding errors of speeds
+	speedEpsilon = 1e-6
+
+	// sneakSpeed is a move speed of a sneaking Hero, which makes no noise
+	sneakSpeed = 1.5
+
+	// radiuses of noises made by Hero
+	runNoise     = 150
+	landingNoise = 250
+	troveNoise   = 300
 )
 
 // Hero is a playbale character.
@@ -40,17 +52,39 @@ type Hero struct {
 	// coordinates
 	altitude int8
 
-	// shape
-	x, y int32
+	// shape, position is the top left corner
+	pos  vec.Vec
 	w, h int32
 
 	// speed
-	vertSpeed float32
-	horSpeed  float32
-	altSpeed  float32
+	vel      vec.Vec
+	altSpeed float32
 
 	crashingDepth int8
 	dead          bool
+	sneaking      bool
+	pounding      bool
+
+	// abilities
+	stamina float64
+	readyAt map[ability.Type]int64
+	// lockedUntil is a tick, till which Hero can't change direction
+	lockedUntil int64
+	// dashing is set, while Hero is faster than it runs after a dash
+	dashing bool
+
+	// health, zero maxHealth means that every hit is fatal
+	health            int
+	maxHealth         int
+	invulnerableUntil int64
+
+	// items' effects
+	activeUntil map[item.Type]int64
+	lives       int
+	// deaths is a number of lives lost through the whole game, it survives restarts
+	deaths int
+
+	inventory Inventory
 
 	// World
 	world *world.World
@@ -59,10 +93,21 @@ type Hero struct {
 // NewHero creates new instance of Hero in given coordinates.
 func NewHero(id string, x, y int32, w *world.World) *Hero {
 	h := &Hero{ID: id}
-	return h.setDefaults(x, y, heroW, heroH, w)
+	h.setDefaults(x, y, heroW, heroH, w)
+	h.reset()
+	return h
 }
 
+// setDefaults puts Hero in the initial state, extra lives and effects carry on.
 func (h *Hero) setDefaults(x, y, heroWidth, heroHeight int32, w *world.World) *Hero {
+	// effects last as long as they were to, once time starts over
+	for it, until := range h.activeUntil {
+		if until > h.time {
+			h.activeUntil[it] = until - h.time
+		} else {
+			delete(h.activeUntil, it)
+		}
+	}
 	h.time = 0
 
 	// properties
@@ -74,17 +119,27 @@ func (h *Hero) setDefaults(x, y, heroWidth, heroHeight int32, w *world.World) *H
 	h.altitude = 0
 
 	// shape
-	h.x = x
-	h.y = y
+	h.pos = vec.Vec{X: float64(x), Y: float64(y)}
 	h.h = heroHeight
 	h.w = heroWidth
 
-	h.vertSpeed = 0
-	h.horSpeed = 0
+	h.vel = vec.Vec{}
 	h.altSpeed = 0
 
 	h.crashingDepth = 0
 	h.dead = false
+	h.sneaking = false
+	h.pounding = false
+
+	h.stamina = maxStamina
+	h.readyAt = make(map[ability.Type]int64, len(costs))
+	h.lockedUntil = 0
+	h.dashing = false
+
+	h.health = h.maxHealth
+	h.invulnerableUntil = 0
+
+	h.inventory = newInventory()
 
 	h.world = w
 
@@ -96,23 +151,63 @@ func (h *Hero) Do(t command.Type) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	// can't move if not within alowed marging from the ground
-	if h.altitude > altitudeMargin {
+	if t == command.GroundPound {
+		h.groundPound()
+		return
+	}
+
+	// can't move if not within alowed marging from the ground or while dashing
+	if h.altitude > h.world.Physics.AltitudeMargin || h.isLocked() || h.pounding {
 		return
 	}
 
 	switch t {
+	case command.Dash:
+		h.dash()
+		return
 	case command.Jump:
-		h.altSpeed = h.maxJumpSpeed
+		if h.altitude == 0 && h.altSpeed == 0 {
+			h.world.Audio.Play(audio.Jump)
+		}
+		h.altSpeed = h.jumpSpeed()
 	case command.GoNorth:
-		h.vertSpeed = -h.maxMoveSpeed
+		h.vel.Y = -h.moveSpeed()
 	case command.GoSouth:
-		h.vertSpeed = h.maxMoveSpeed
+		h.vel.Y = h.moveSpeed()
 	case command.GoWest:
-		h.horSpeed = -h.maxMoveSpeed
+		h.vel.X = -h.moveSpeed()
 	case command.GoEast:
-		h.horSpeed = h.maxMoveSpeed
+		h.vel.X = h.moveSpeed()
 	}
+	// moving diagonally is not any faster
+	h.vel = h.vel.Limit(h.moveSpeed())
+}
+
+// Sneak makes Hero to move slowly without making any noise and to be harder to spot, while "on" is set
+// and Hero has stamina.
+func (h *Hero) Sneak(on bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sneaking = on && h.ready(ability.Sneak)
+}
+
+// moveSpeed returns a speed Hero starts moving with.
+func (h *Hero) moveSpeed() float64 {
+	if h.sneaking {
+		return sneakSpeed
+	}
+	if h.active(item.Speed) {
+		return float64(h.maxMoveSpeed) * speedBoost
+	}
+	return float64(h.maxMoveSpeed)
+}
+
+// jumpSpeed returns a speed Hero starts jumping with.
+func (h *Hero) jumpSpeed() float32 {
+	if h.active(item.Jump) {
+		return h.maxJumpSpeed * jumpBoost
+	}
+	return h.maxJumpSpeed
 }
 
 // Update updates state of the Hero.
@@ -121,16 +216,23 @@ func (h *Hero) Update() {
 	defer h.mu.Unlock()
 
 	h.time++
+	h.handleStamina()
 
-	if h.horSpeed != 0 || h.vertSpeed != 0 {
+	if h.isRunning() {
+		h.makeNoise(runNoise)
+	}
+	if !h.vel.IsZero() {
 		h.handleMove()
 	}
+	h.endDash()
 	if h.crashingDepth == 0 && h.altSpeed != 0 {
 		h.handleJump()
 	}
 	if h.crashingDepth != 0 {
 		h.handleCrash()
 	}
+
+	h.world.Move(h.ID, h.getFootprint(), float64(h.altitude))
 }
 
 // Paint paints Hero to window.
@@ -138,6 +240,11 @@ func (h *Hero) Paint(r *sdl.Renderer) error {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	// blink while invulnerable
+	if h.isInvulnerable() && h.time/5%2 == 0 {
+		return nil
+	}
+
 	// fill new rectangle
 	r.SetDrawColor(0, 160, 0, 255)
 	r.FillRect(h.getShape())
@@ -150,12 +257,23 @@ func (h *Hero) Paint(r *sdl.Renderer) error {
 	return nil
 }
 
-// Restart restarts state of Hero.
-func (h *Hero) Restart() {
+// Respawn restarts state of Hero at the given position, e.g. on the next level, it keeps extra lives and effects.
+func (h *Hero) Respawn(x, y int32) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	pos := h.world.RandomizePos(h.ID, heroW, heroH)
-	h.setDefaults(pos.X, pos.Y, pos.W, pos.H, h.world)
+	h.setDefaults(x, y, heroW, heroH, h.world)
+}
+
+// Reset takes away extra lives and effects of Hero, once a new game starts.
+func (h *Hero) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reset()
+}
+
+func (h *Hero) reset() {
+	h.activeUntil = make(map[item.Type]int64, len(effects))
+	h.lives = 0
 }
 
 // Destroy removes Hero.
@@ -164,31 +282,33 @@ func (h *Hero) Destroy() {
 }
 
 func (h *Hero) getShape() *sdl.Rect {
+	r := h.getFootprint()
 	if h.altitude != 0 {
 		return &sdl.Rect{
-			X: h.x - int32(h.altitude/2),
-			Y: h.y - int32(h.altitude/2),
-			W: h.w + int32(h.altitude),
-			H: h.h + int32(h.altitude),
+			X: r.X - int32(h.altitude/2),
+			Y: r.Y - int32(h.altitude/2),
+			W: r.W + int32(h.altitude),
+			H: r.H + int32(h.altitude),
 		}
 	}
-	return &sdl.Rect{
-		X: h.x,
-		Y: h.y,
-		W: h.w,
-		H: h.h,
-	}
+	return r
+}
+
+// getFootprint returns area occupied by the Hero on the ground, rounded to pixels.
+func (h *Hero) getFootprint() *sdl.Rect {
+	p := h.pos.Point()
+	return &sdl.Rect{X: p.X, Y: p.Y, W: h.w, H: h.h}
 }
 
 func (h *Hero) handleCrash() {
 	// crashing
 	if h.altitude > h.crashingDepth {
-		h.altSpeed -= gravity
+		h.altSpeed -= h.world.Physics.Gravity
 		h.altitude += int8(h.altSpeed)
 	} else { // crashed
 		h.altSpeed = 0
 		h.altitude = h.crashingDepth
-		h.dead = true
+		h.loseLife()
 	}
 }
 
@@ -196,32 +316,37 @@ func (h *Hero) handleJump() {
 	// rising
 	if h.altSpeed > 0 {
 		h.altitude += int8(h.altSpeed)
-		h.altSpeed -= gravity
+		h.altSpeed -= h.world.Physics.Gravity
 		return
 	}
 
 	// falling
 	if h.altitude > 0 && h.altSpeed <= 0 {
 		h.altitude = int8(math.Max(0, float64(h.altitude)+float64(h.altSpeed)))
-		h.altSpeed -= gravity
+		h.altSpeed -= h.world.Physics.Gravity
 		return
 	}
 
 	// landed
 	if h.altitude == 0 && h.altSpeed < 0 {
 		h.altSpeed = 0
+		if h.pounding {
+			h.impact()
+			return
+		}
+		h.makeNoise(landingNoise)
 		return
 	}
 }
 
 func (h *Hero) canGoHorizontal() bool {
 	// going right
-	if h.horSpeed > 0 && h.x+h.width >= h.world.W {
+	if h.vel.X > 0 && h.pos.X+float64(h.width) >= float64(h.world.W) {
 		return false
 	}
 
 	// going left
-	if h.horSpeed < 0 && h.x <= 0 {
+	if h.vel.X < 0 && h.pos.X <= 0 {
 		return false
 	}
 
@@ -230,12 +355,12 @@ func (h *Hero) canGoHorizontal() bool {
 
 func (h *Hero) canGoVertical() bool {
 	// going up
-	if h.vertSpeed < 0 && h.y <= 0 {
+	if h.vel.Y < 0 && h.pos.Y <= 0 {
 		return false
 	}
 
 	// going down
-	if h.vertSpeed > 0 && h.y+h.height >= h.world.H {
+	if h.vel.Y > 0 && h.pos.Y+float64(h.height) >= float64(h.world.H) {
 		return false
 	}
 
@@ -243,29 +368,30 @@ func (h *Hero) canGoVertical() bool {
 }
 
 func (h *Hero) handleMove() {
-	var frict float64
+	frict, speed := h.world.Physics.AirFriction, 1.0
 	if h.altitude == 0 {
-		frict = friction
-	} else {
-		frict = airFriction
+		surf := h.world.SurfaceAt(h.center())
+		frict, speed = h.world.Physics.Friction*surf.Friction, surf.Speed
 	}
 
-	if h.horSpeed != 0 && h.canGoHorizontal() {
-		h.x += int32(h.horSpeed)
-		if h.horSpeed > 0 {
-			h.horSpeed = float32(math.Max(0, float64(h.horSpeed)-frict))
-		} else {
-			h.horSpeed = float32(math.Min(0, float64(h.horSpeed)+frict))
-		}
+	step := h.vel.Scale(speed)
+	if !h.canGoHorizontal() || h.blocked(vec.Vec{X: step.X}) {
+		step.X = 0
 	}
-	if h.vertSpeed != 0 && h.canGoVertical() {
-		h.y += int32(h.vertSpeed)
-		if h.vertSpeed > 0 {
-			h.vertSpeed = float32(math.Max(0, float64(h.vertSpeed)-frict))
-		} else {
-			h.vertSpeed = float32(math.Min(0, float64(h.vertSpeed)+frict))
-		}
+	if !h.canGoVertical() || h.blocked(vec.Vec{Y: step.Y}) {
+		step.Y = 0
 	}
+	h.pos = h.pos.Add(step)
+	h.vel = h.vel.Shrink(frict)
+}
+
+// blocked tells whether a solid object stands in the way of the Hero's "step".
+func (h *Hero) blocked(step vec.Vec) bool {
+	if step.IsZero() {
+		return false
+	}
+	p := h.pos.Add(step).Point()
+	return h.world.Blocked(&sdl.Rect{X: p.X, Y: p.Y, W: h.w, H: h.h})
 }
 
 // TouchPit checks collision with Pit.
@@ -276,45 +402,141 @@ func (h *Hero) TouchPit(p *pit.Pit) {
 	if h.altitude > 0 { // above in the air
 		return
 	}
-	if p.X > h.x+h.w-collisionMargin { // too far right
+	r, m, b := h.getFootprint(), h.world.Physics.CollisionMargin, p.Bounds()
+	if b.X > r.X+r.W-m { // too far right
 		return
 	}
-	if p.X+p.W-collisionMargin < h.x { // too far left
+	if b.X+b.W-m < r.X { // too far left
 		return
 	}
-	if p.Y > h.y+h.h-collisionMargin { // too far below
+	if b.Y > r.Y+r.H-m { // too far below
 		return
 	}
-	if p.Y+p.H-collisionMargin < h.y { // to far above
+	if b.Y+b.H-m < r.Y { // to far above
+		return
+	}
+
+	// closed pit is a floor, crumbling one gives way after a while
+	if !p.IsOpen() {
+		p.Step()
 		return
 	}
 
+	// Hero climbs out of a shallow pit hurt, unless every hit is fatal
+	if p.IsShallow() && h.maxHealth > 0 {
+		h.hurt(pitDamage, sdl.Point{X: b.X + b.W/2, Y: b.Y + b.H/2})
+		return
+	}
+
+	if h.crashingDepth == 0 {
+		h.world.Audio.Play(audio.Fall)
+	}
 	h.crashingDepth = p.Depth()
 }
 
-// TouchTrove checks collision with Pit.
+// TouchTrove checks collision with Trove, heavy one is collected after Hero stands still on it for a while.
 func (h *Hero) TouchTrove(t *trove.Trove) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if h.altitude > 0 { // above in the air
+	if h.altitude > 0 || !t.IsThere() { // above in the air or nothing to collect
+		return
+	}
+	r, m, b := h.getFootprint(), h.world.Physics.CollisionMargin, t.Bounds()
+	if b.X > r.X+r.W-m { // too far right
 		return
 	}
-	if t.X > h.x+h.w-collisionMargin { // too far right
+	if b.X+b.W-m < r.X { // too far left
 		return
 	}
-	if t.X+t.W-collisionMargin < h.x { // too far left
+	if b.Y > r.Y+r.H-m { // too far below
 		return
 	}
-	if t.Y > h.y+h.h-collisionMargin { // too far below
+	if b.Y+b.H-m < r.Y { // to far above
 		return
 	}
-	if t.Y+t.H-collisionMargin < h.y { // to far above
+	if !t.Lift(h.vel.IsZero()) {
 		return
 	}
 
 	t.Collect()
-	h.world.IncScore()
+	h.world.Collect(t.Value())
+	h.world.Audio.Play(audio.Collect)
+	h.makeNoise(troveNoise)
+}
+
+// TouchExit checks collision with Exit, Hero finishes the level, if the Exit is open.
+func (h *Hero) TouchExit(e *exit.Exit) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.altitude > 0 { // above in the air
+		return
+	}
+	r, m, b := h.getFootprint(), h.world.Physics.CollisionMargin, e.Bounds()
+	if b.X > r.X+r.W-m || b.X+b.W-m < r.X || b.Y > r.Y+r.H-m || b.Y+b.H-m < r.Y {
+		return
+	}
+	e.Reach()
+}
+
+// TouchPickup checks collision with Pickup.
+func (h *Hero) TouchPickup(p *pickup.Pickup) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.altitude > 0 { // above in the air
+		return
+	}
+	r, m := h.getFootprint(), h.world.Physics.CollisionMargin
+	if p.X > r.X+r.W-m || p.X+p.W-m < r.X || p.Y > r.Y+r.H-m || p.Y+p.H-m < r.Y {
+		return
+	}
+
+	switch p.Item {
+	case item.Health:
+		// health pickups stay for later, while Hero is healthy
+		if !h.heal(healthPickup) {
+			return
+		}
+	case item.Gem:
+		h.world.AddScore(score.Gem, p.Value)
+	case item.Key:
+		h.inventory.Keys[p.Key]++
+	case item.Life:
+		if h.lives >= maxLives {
+			return
+		}
+		h.lives++
+	default:
+		if !h.apply(p.Item) {
+			return
+		}
+	}
+
+	p.Collect()
+	h.world.Audio.Play(audio.Collect)
+}
+
+// isRunning tells whether Hero moves on the ground at full speed.
+func (h *Hero) isRunning() bool {
+	if h.altitude != 0 {
+		return false
+	}
+	return h.vel.Len() >= float64(h.maxMoveSpeed)-speedEpsilon
+}
+
+// makeNoise makes noise of the given "radius" around Hero, unless Hero sneaks.
+func (h *Hero) makeNoise(radius int32) {
+	if h.sneaking {
+		return
+	}
+	h.world.MakeNoise(h.center(), radius)
+}
+
+// center returns center of the Hero.
+func (h *Hero) center() sdl.Point {
+	return h.pos.Add(vec.Vec{X: float64(h.w) / 2, Y: float64(h.h) / 2}).Point()
 }
 
 // Location returns a location of the Hero.
@@ -335,5 +557,23 @@ func (h *Hero) IsDead() bool {
 func (h *Hero) Die() {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	h.countDeath()
+	h.die()
+}
+
+// countDeath counts a life lost, unless Hero is already dead, and takes the penalty.
+func (h *Hero) countDeath() {
+	if h.dead {
+		return
+	}
+	h.deaths++
+	h.world.AddScore(score.Death, -world.DeathPenalty)
+}
+
+func (h *Hero) die() {
+	if h.dead {
+		return
+	}
 	h.dead = true
+	h.world.Audio.Play(audio.Death)
 }
@@ -0,0 +1,194 @@
+package hero
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/exit"
+	"github.com/smeshkov/trovehero/pit"
+	"github.com/smeshkov/trovehero/trove"
+	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/types/terrain"
+	"github.com/smeshkov/trovehero/types/treasure"
+	"github.com/smeshkov/trovehero/vec"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Update_running_makes_noise(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Do(command.GoEast)
+	h.Update()
+
+	assert.NotEmpty(t, w.NoisesAt(sdl.Point{X: 525 + runNoise - 10, Y: 525}))
+}
+
+func Test_Update_sneaking_is_silent(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Sneak(true)
+	h.Do(command.GoEast)
+	h.Do(command.Jump)
+	for i := 0; i < 100; i++ {
+		h.Update()
+	}
+
+	assert.Empty(t, w.NoisesAt(sdl.Point{X: 525, Y: 525}))
+	assert.True(t, h.getFootprint().X-500 < 50, "sneaking Hero is slow")
+}
+
+func Test_Update_landing_makes_noise(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Do(command.Jump)
+	landed := false
+	for i := 0; i < 200 && !landed; i++ {
+		h.Update()
+		landed = len(w.NoisesAt(sdl.Point{X: 525, Y: 525})) > 0
+		w.Update()
+	}
+
+	assert.True(t, landed)
+}
+
+func Test_Do_diagonal_is_not_faster(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Do(command.GoEast)
+	h.Do(command.GoSouth)
+
+	assert.InDelta(t, h.maxMoveSpeed, h.vel.Len(), 1e-6)
+}
+
+func Test_Update_dash_ends_at_running_speed(t *testing.T) {
+	w := world.NewWorld(2000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 100, 500, w)
+
+	h.Do(command.GoEast)
+	h.Do(command.Dash)
+	for i := 1; i < dashTicks; i++ {
+		h.Update()
+	}
+	assert.Greater(t, h.vel.Len(), h.moveSpeed(), "Hero is still dashing")
+
+	h.Update()
+	assert.False(t, h.isLocked())
+	assert.LessOrEqual(t, h.vel.Len(), h.moveSpeed(), "Hero is back to running speed")
+	for i := 0; i < dashTicks; i++ {
+		h.Update()
+		assert.LessOrEqual(t, h.vel.Len(), h.moveSpeed())
+	}
+}
+
+func Test_Update_keeps_sub_pixel_motion(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+
+	h.Do(command.GoEast)
+	for i := 0; i < 100; i++ {
+		h.Update()
+	}
+
+	// friction slows Hero down from full speed to a halt, every fraction of a pixel counts
+	v := float64(h.maxMoveSpeed)
+	var want float64
+	for v > 0 {
+		want += v
+		v -= w.Physics.Friction
+	}
+	assert.InDelta(t, 500+want, h.pos.X, 1)
+	assert.Equal(t, int32(math.Round(h.pos.X)), h.Location().X)
+}
+
+// distance returns distance covered by Hero, which runs east for "run" ticks on the given terrain and then stops.
+func distance(tr terrain.Type, run int) float64 {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.AddZone(world.Zone{ID: "zone-0", Bounds: sdl.Rect{X: 0, Y: 0, W: 1000, H: 1000}, Terrain: tr})
+	h := NewHero("hero", 100, 500, w)
+
+	for i := 0; i < run; i++ {
+		h.Do(command.GoEast)
+		h.Update()
+	}
+	for i := 0; i < 300; i++ {
+		h.Update()
+	}
+	return h.pos.X - 100
+}
+
+func Test_Update_terrain(t *testing.T) {
+	ground := distance(terrain.Ground, 20)
+
+	assert.True(t, distance(terrain.Ice, 20) > ground, "Hero slides on ice")
+	assert.True(t, distance(terrain.Mud, 20) < ground, "Hero is slow in mud")
+	assert.True(t, distance(terrain.Sand, 20) < ground, "Hero is slow on sand")
+}
+
+func Test_TouchPit_crumbling(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	p := pit.NewCrumblingPit("pit", 500, 500, 50, 50, 100, w)
+
+	h.TouchPit(p)
+	assert.Equal(t, int8(0), h.crashingDepth, "tile holds")
+
+	for !p.IsOpen() {
+		p.Update()
+	}
+	h.TouchPit(p)
+	assert.Equal(t, int8(100), h.crashingDepth)
+}
+
+func Test_TouchTrove_heavy(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	tr := trove.NewHeavyTrove("trove", 500, 500, w)
+
+	h.Do(command.GoEast)
+	for i := 0; i < trove.LiftTicks; i++ {
+		h.TouchTrove(tr)
+		tr.Update()
+	}
+	assert.False(t, tr.IsCollected(), "Hero runs")
+
+	h.vel = vec.Vec{}
+	for i := 0; i < trove.LiftTicks; i++ {
+		h.TouchTrove(tr)
+		tr.Update()
+	}
+	assert.True(t, tr.IsCollected())
+	assert.Equal(t, trove.Values[treasure.Heavy], w.GetScore())
+}
+
+func Test_TouchTrove_optional(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	tr := trove.NewTrove("trove", 500, 500, w)
+	tr.Optional = true
+
+	h.TouchTrove(tr)
+
+	assert.True(t, tr.IsCollected())
+	assert.Equal(t, trove.Values[treasure.Static]+trove.Bonus, w.GetScore())
+}
+
+func Test_TouchExit(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	e := exit.NewExit("exit", 520, 520, w)
+
+	h.TouchExit(e)
+	assert.False(t, e.IsReached())
+
+	e.Unlock()
+	h.TouchExit(e)
+	assert.True(t, e.IsReached())
+}
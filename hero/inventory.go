@@ -0,0 +1,51 @@
+package hero
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/door"
+	"github.com/smeshkov/trovehero/types/key"
+)
+
+// doorReach is a distance, from which Hero can open a door.
+const doorReach = 12
+
+// Inventory holds items carried by the Hero.
+type Inventory struct {
+	// Keys is a number of keys of every color
+	Keys map[key.Type]int
+}
+
+func newInventory() Inventory {
+	return Inventory{Keys: make(map[key.Type]int)}
+}
+
+// Inventory returns a copy of the Hero's Inventory.
+func (h *Hero) Inventory() Inventory {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	inv := newInventory()
+	for k, n := range h.inventory.Keys {
+		inv.Keys[k] = n
+	}
+	return inv
+}
+
+// TouchDoor opens the Door "d" with a key of its color, if Hero is next to the Door and has one.
+func (h *Hero) TouchDoor(d *door.Door) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if d.IsOpen() || h.inventory.Keys[d.Key] == 0 {
+		return
+	}
+	r := h.getFootprint()
+	near := &sdl.Rect{X: r.X - doorReach, Y: r.Y - doorReach, W: r.W + 2*doorReach, H: r.H + 2*doorReach}
+	if !near.HasIntersection(&sdl.Rect{X: d.X, Y: d.Y, W: d.W, H: d.H}) {
+		return
+	}
+
+	h.inventory.Keys[d.Key]--
+	d.Open()
+}
@@ -0,0 +1,63 @@
+package hero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/door"
+	"github.com/smeshkov/trovehero/pickup"
+	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/types/key"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_TouchDoor(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	d := door.NewDoor("door-0", 555, 490, 10, 70, key.Blue, w)
+
+	h.TouchDoor(d)
+	assert.False(t, d.IsOpen(), "Hero has no key")
+
+	h.TouchPickup(pickup.NewKey("key-0", 510, 510, key.Red, w))
+	h.TouchDoor(d)
+	assert.False(t, d.IsOpen(), "key of another color")
+
+	h.TouchPickup(pickup.NewKey("key-1", 510, 510, key.Blue, w))
+	assert.Equal(t, 1, h.Inventory().Keys[key.Blue])
+	h.TouchDoor(d)
+	assert.True(t, d.IsOpen())
+	assert.Equal(t, 0, h.Inventory().Keys[key.Blue])
+	assert.Equal(t, 1, h.Inventory().Keys[key.Red])
+}
+
+func Test_TouchDoor_too_far(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	d := door.NewDoor("door-0", 600, 490, 10, 70, key.Blue, w)
+
+	h.TouchPickup(pickup.NewKey("key-0", 510, 510, key.Blue, w))
+	h.TouchDoor(d)
+
+	assert.False(t, d.IsOpen())
+}
+
+func Test_Update_blocked_by_wall(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	h := NewHero("hero", 500, 500, w)
+	w.Register("wall-0", kind.Wall, &sdl.Rect{X: 600, Y: 0, W: 10, H: 1000})
+
+	for i := 0; i < 100; i++ {
+		h.Do(command.GoEast)
+		h.Do(command.GoSouth)
+		h.Update()
+	}
+
+	r := h.getFootprint()
+	assert.True(t, r.X+r.W <= 600, "Hero stays in front of the wall")
+	assert.True(t, r.Y > 600, "Hero slides along the wall")
+}
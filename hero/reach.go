@@ -0,0 +1,53 @@
+package hero
+
+import (
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/physics"
+	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/world"
+)
+
+// Reach describes how far Hero can get, e.g. to verify that a level can be completed.
+type Reach struct {
+	// size of the Hero
+	W, H int32
+	// Margin is a collision margin, objects have to overlap Hero deeper than it to touch
+	Margin int32
+	// Jump is the distance Hero covers with a single jump at full speed
+	Jump int32
+}
+
+// DefaultReach measures Reach of the Hero with default properties and physics.
+func DefaultReach() Reach {
+	return NewReach(physics.DefaultConfig())
+}
+
+// NewReach measures Reach of the Hero with default properties under the given physics "c".
+func NewReach(c physics.Config) Reach {
+	return Reach{
+		W:      heroW,
+		H:      heroH,
+		Margin: c.CollisionMargin,
+		Jump:   jumpDistance(c),
+	}
+}
+
+// jumpDistance simulates a jump on the ground of an endless world in order to stay in line with the physics of Hero.
+func jumpDistance(c physics.Config) int32 {
+	const size = 1 << 20
+
+	w := world.NewWorld(size, size, nil, 0, audio.Silent{})
+	w.Physics = c
+	h := NewHero("", size/2, size/2, w)
+
+	h.Do(command.GoEast)
+	h.Do(command.Jump)
+	h.Update()
+	for h.altitude > 0 {
+		// direction is held during the jump
+		h.Do(command.GoEast)
+		h.Update()
+	}
+
+	return h.getFootprint().X - size/2
+}
@@ -0,0 +1,235 @@
+// Package highscore keeps the best results of the game in a local file.
+package highscore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// Max is a number of entries kept in the table.
+	Max = 10
+	// Anonymous is a name of the entry, which has been recorded without initials.
+	Anonymous = "???"
+
+	fileName = "scores.json"
+)
+
+// Entry is a single result in the table.
+type Entry struct {
+	Name     string        `json:"name"`
+	Score    int           `json:"score"`
+	Level    int           `json:"level"`
+	Seed     int64         `json:"seed"`
+	Date     time.Time     `json:"date"`
+	PlayTime time.Duration `json:"playTime"`
+}
+
+// valid tells whether the Entry could have been recorded by the game.
+func (e Entry) valid() bool {
+	return strings.TrimSpace(e.Name) != "" && e.Score > 0 && e.Level >= 0 && e.PlayTime >= 0
+}
+
+// Store keeps the table of the best results in a file.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates new instance of the Store, which keeps the table in the file at "path".
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns a path to the table in the user's data directory.
+func DefaultPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find data directory: %w", err)
+	}
+	return filepath.Join(dir, "trovehero", fileName), nil
+}
+
+// dataDir returns a directory for the user's data, XDG one on Unix systems.
+func dataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// Path returns a path to the file of the table.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load returns entries of the table from the best to the worst, the table is empty, if there is no file yet.
+// Corrupted file is set aside and the table starts anew, invalid entries are dropped.
+func (s *Store) Load() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+func (s *Store) load() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read high scores: %w", err)
+	}
+
+	var all []Entry
+	if err := json.Unmarshal(data, &all); err != nil {
+		log.Printf("high scores %s are corrupted and start anew: %v", s.path, err)
+		if err := os.Rename(s.path, s.path+".bad"); err != nil {
+			log.Printf("could not set aside corrupted high scores: %v", err)
+		}
+		return nil, nil
+	}
+
+	entries := make([]Entry, 0, len(all))
+	for _, e := range all {
+		if e.valid() {
+			entries = append(entries, e)
+		}
+	}
+	rank(entries)
+	if len(entries) > Max {
+		entries = entries[:Max]
+	}
+	return entries, nil
+}
+
+// Qualifies tells whether the "score" makes it into the table.
+func (s *Store) Qualifies(score int) (bool, error) {
+	entries, err := s.Load()
+	if err != nil {
+		return false, err
+	}
+	return qualifies(entries, score), nil
+}
+
+func qualifies(entries []Entry, score int) bool {
+	if score <= 0 {
+		return false
+	}
+	return len(entries) < Max || score > entries[len(entries)-1].Score
+}
+
+// Add records the Entry "e" and returns its rank starting from 1, or 0, if it doesn't make it into the table.
+func (s *Store) Add(e Entry) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return 0, err
+	}
+	if !qualifies(entries, e.Score) {
+		return 0, nil
+	}
+	e.Name = strings.ToUpper(strings.TrimSpace(e.Name))
+	if e.Name == "" {
+		e.Name = Anonymous
+	}
+
+	// earlier entries keep their places on a tie
+	i := sort.Search(len(entries), func(i int) bool { return less(e, entries[i]) })
+	entries = append(entries, Entry{})
+	copy(entries[i+1:], entries[i:])
+	entries[i] = e
+	if len(entries) > Max {
+		entries = entries[:Max]
+	}
+
+	if err := s.save(entries); err != nil {
+		return 0, err
+	}
+	return i + 1, nil
+}
+
+// save writes the table to a temporary file first and then replaces the old one with it,
+// so that the table is never left half-written.
+func (s *Store) save(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode high scores: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("could not create directory of high scores: %w", err)
+	}
+	f, err := os.CreateTemp(dir, fileName+".*")
+	if err != nil {
+		return fmt.Errorf("could not create high scores: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write high scores: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write high scores: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not write high scores: %w", err)
+	}
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("could not replace high scores: %w", err)
+	}
+	return nil
+}
+
+// less tells whether the Entry "a" ranks higher than "b", the higher score wins, then the higher level.
+func less(a, b Entry) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Level > b.Level
+}
+
+// rank sorts entries from the best to the worst.
+func rank(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool { return less(entries[i], entries[j]) })
+}
+
+// Print writes the table of "entries" to "w" in aligned columns.
+func Print(w io.Writer, entries []Entry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tName\tScore\tLevel\tTime\tDate\tSeed")
+	for i, e := range entries {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%s\t%s\t%d\n",
+			i+1, e.Name, e.Score, e.Level, Clock(e.PlayTime), e.Date.Local().Format("2006-01-02"), e.Seed)
+	}
+	return tw.Flush()
+}
+
+// Clock formats the duration "d" as minutes and seconds, e.g. 12:05.
+func Clock(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,133 @@
+package highscore
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(t *testing.T) *Store {
+	return NewStore(filepath.Join(t.TempDir(), "trovehero", fileName))
+}
+
+func Test_Load_no_file(t *testing.T) {
+	s := newTestStore(t)
+
+	entries, err := s.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	ok, err := s.Qualifies(1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func Test_Add(t *testing.T) {
+	s := newTestStore(t)
+	date := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	rank, err := s.Add(Entry{Name: "abc", Score: 10, Level: 2, Seed: 42, Date: date, PlayTime: time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rank)
+
+	rank, err = s.Add(Entry{Name: "xyz", Score: 20, Level: 3, Date: date})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rank, "higher score comes first")
+
+	rank, err = s.Add(Entry{Name: "dup", Score: 10, Level: 2, Date: date})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rank, "earlier entry keeps its place on a tie")
+
+	rank, err = s.Add(Entry{Name: " ", Score: 10, Level: 5, Date: date})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rank, "higher level wins on the same score")
+
+	entries, err := NewStore(s.Path()).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "XYZ", Score: 20, Level: 3, Date: date},
+		{Name: Anonymous, Score: 10, Level: 5, Date: date},
+		{Name: "ABC", Score: 10, Level: 2, Seed: 42, Date: date, PlayTime: time.Minute},
+		{Name: "DUP", Score: 10, Level: 2, Date: date},
+	}, entries)
+}
+
+func Test_Add_full(t *testing.T) {
+	s := newTestStore(t)
+	for i := 1; i <= Max; i++ {
+		_, err := s.Add(Entry{Name: "AAA", Score: i * 10})
+		assert.NoError(t, err)
+	}
+
+	ok, err := s.Qualifies(10)
+	assert.NoError(t, err)
+	assert.False(t, ok, "ties with the last entry")
+
+	rank, err := s.Add(Entry{Name: "BBB", Score: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, rank)
+
+	rank, err = s.Add(Entry{Name: "BBB", Score: 15})
+	assert.NoError(t, err)
+	assert.Equal(t, Max, rank)
+
+	entries, err := s.Load()
+	assert.NoError(t, err)
+	assert.Len(t, entries, Max)
+	assert.Equal(t, 15, entries[Max-1].Score, "the worst entry drops out")
+}
+
+func Test_Add_no_score(t *testing.T) {
+	s := newTestStore(t)
+
+	rank, err := s.Add(Entry{Name: "AAA"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, rank)
+
+	_, err = os.Stat(s.Path())
+	assert.True(t, os.IsNotExist(err), "nothing is written")
+}
+
+func Test_Load_corrupted(t *testing.T) {
+	s := newTestStore(t)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(s.Path()), 0o755))
+	assert.NoError(t, os.WriteFile(s.Path(), []byte(`[{"name":"AAA","score":`), 0o644))
+
+	entries, err := s.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.FileExists(t, s.Path()+".bad", "corrupted file is set aside")
+
+	rank, err := s.Add(Entry{Name: "AAA", Score: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rank)
+}
+
+func Test_Load_invalid_entries(t *testing.T) {
+	s := newTestStore(t)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(s.Path()), 0o755))
+	assert.NoError(t, os.WriteFile(s.Path(), []byte(`[
+		{"name":"AAA","score":5},
+		{"name":"","score":50},
+		{"name":"BBB","score":-1},
+		{"name":"CCC","score":7}
+	]`), 0o644))
+
+	entries, err := s.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{{Name: "CCC", Score: 7}, {Name: "AAA", Score: 5}}, entries)
+}
+
+func Test_Print(t *testing.T) {
+	var b bytes.Buffer
+	err := Print(&b, []Entry{
+		{Name: "ABC", Score: 120, Level: 4, Seed: 42, Date: time.Date(2021, 5, 1, 12, 0, 0, 0, time.Local), PlayTime: 125 * time.Second},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "#  Name  Score  Level  Time  Date        Seed\n"+
+		"1  ABC   120    4      2:05  2021-05-01  42\n", b.String())
+}
@@ -0,0 +1,685 @@
+// Package level generates levels of the game.
+package level
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/difficulty"
+	"github.com/smeshkov/trovehero/door"
+	"github.com/smeshkov/trovehero/enemy"
+	"github.com/smeshkov/trovehero/exit"
+	"github.com/smeshkov/trovehero/hero"
+	"github.com/smeshkov/trovehero/pickup"
+	"github.com/smeshkov/trovehero/types/hazard"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/key"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/terrain"
+	"github.com/smeshkov/trovehero/types/trap"
+	"github.com/smeshkov/trovehero/types/treasure"
+	"github.com/smeshkov/trovehero/wall"
+	"github.com/smeshkov/trovehero/world"
+)
+
+const (
+	// HeroID is an ID of the Hero in generated levels.
+	HeroID = "hero"
+	// ExitID is an ID of the exit in generated levels.
+	ExitID = "exit"
+
+	// number of attempts with full density, before generator starts to drop pits
+	fullAttempts = 10
+
+	// heroPitMargin keeps pits away from the Hero's spawn point.
+	heroPitMargin = 150
+
+	minPitSize = 30
+
+	// minTravel and maxTravel limit a distance moving pits travel.
+	minTravel = 100
+	maxTravel = 200
+	// minPeriod and maxPeriod limit a number of ticks timed pits stay open or closed.
+	minPeriod = 100
+	maxPeriod = 300
+
+	// tileSize is a side of spikes and fire tiles.
+	tileSize    = 50
+	plateSize   = 30
+	boulderSize = 40
+	arrowSize   = 10
+	// minRoll and maxRoll limit a distance boulders roll.
+	minRoll = 200
+	maxRoll = 400
+	// arrowRange is a distance arrows fly from their start to a plate and then past it.
+	arrowRange = 300
+
+	// minZoneSize is the smallest side of a terrain zone.
+	minZoneSize = 80
+	// zoneAttempts is a number of attempts to fit a zone in between pits.
+	zoneAttempts = 20
+
+	// troveSize and enemySize are sides of troves and enemies.
+	troveSize = 50
+	enemySize = 50
+
+	// patrolSize is a side of a square route of patrollers.
+	patrolSize = 200
+	// patrolWait is a number of ticks patrollers look around at some waypoints.
+	patrolWait = 120
+
+	// exitMargin keeps the exit away from the Hero's spawn point.
+	exitMargin = 300
+
+	// guardGap is a gap in between a guarded trove and its guard.
+	guardGap = 10
+	// maxDelay is the latest tick, a fleeting trove appears at,
+	// minWindow and maxWindow limit a number of ticks it stays for.
+	maxDelay  = 1000
+	minWindow = 1000
+	maxWindow = 2000
+
+	// roomSize is an inner size of a treasure room, which fits a trove and the Hero next to it.
+	roomSize = 150
+)
+
+// gemValues are values of gems, which add up to the score.
+var gemValues = []int{1, 2, 5}
+
+// ErrUnsolvable is returned when generator fails to produce a level, which can be completed.
+var ErrUnsolvable = errors.New("level is unsolvable")
+
+// Object is a generated object of a level.
+type Object struct {
+	ID     string   `json:"id"`
+	Bounds sdl.Rect `json:"bounds"`
+	// Depth of a pit
+	Depth int8 `json:"depth,omitempty"`
+	// Trap is a kind of a pit
+	Trap trap.Type `json:"trap,omitempty"`
+	// Path of a moving pit, a boulder or an arrow
+	Path []sdl.Point `json:"path,omitempty"`
+	// Period of a timed pit, spikes or fire
+	Period int64 `json:"period,omitempty"`
+	// Hazard is a kind of a hazard
+	Hazard hazard.Type `json:"hazard,omitempty"`
+	// Trigger is an ID of the arrow fired by a plate
+	Trigger string `json:"trigger,omitempty"`
+	// Treasure is a kind of a trove
+	Treasure treasure.Type `json:"treasure,omitempty"`
+	// Optional trove isn't required to unlock the exit
+	Optional bool `json:"optional,omitempty"`
+	// From and Until is a window of a fleeting trove in ticks since the level starts
+	From  int64 `json:"from,omitempty"`
+	Until int64 `json:"until,omitempty"`
+	// Archetype of an enemy
+	Archetype enemy.Archetype `json:"archetype,omitempty"`
+	// Route of a patroller
+	Route enemy.Route `json:"route"`
+	// Item of a pickup
+	Item item.Type `json:"item,omitempty"`
+	// Value of a gem
+	Value int `json:"value,omitempty"`
+	// Key is a color of a key or a door
+	Key key.Type `json:"key,omitempty"`
+}
+
+// Layout is a generated level.
+type Layout struct {
+	// Seed the Layout was generated with.
+	Seed int64 `json:"seed"`
+	// size of the level
+	W int32 `json:"w"`
+	H int32 `json:"h"`
+
+	Hero    Object       `json:"hero"`
+	Exit    Object       `json:"exit"`
+	Pits    []Object     `json:"pits"`
+	Troves  []Object     `json:"troves"`
+	Enemies []Object     `json:"enemies"`
+	Pickups []Object     `json:"pickups"`
+	Hazards []Object     `json:"hazards"`
+	Walls   []Object     `json:"walls"`
+	Doors   []Object     `json:"doors"`
+	Zones   []world.Zone `json:"zones"`
+}
+
+// Generator generates levels in the World, which can be completed by the Hero.
+type Generator struct {
+	world *world.World
+	reach hero.Reach
+}
+
+// NewGenerator creates new instance of Generator.
+func NewGenerator(w *world.World, r hero.Reach) *Generator {
+	return &Generator{world: w, reach: r}
+}
+
+// Generate generates Layout of the level "lvl" with the given difficulty Settings "s" from the given "seed"
+// and registers its objects in the World. Layout is regenerated until every trove can be collected,
+// after a number of attempts pits are dropped one by one, hence a level without pits can always be completed.
+func (g *Generator) Generate(seed int64, lvl int, s difficulty.Settings) (*Layout, error) {
+	attempts := fullAttempts + s.Pits
+	for attempt := 0; attempt <= attempts; attempt++ {
+		if attempt >= fullAttempts && s.Pits > 0 {
+			s.Pits--
+		}
+
+		l, err := g.generate(seed+int64(attempt), s)
+		if err != nil {
+			return nil, err
+		}
+		if l.Solvable(g.reach) {
+			return l, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not generate level %d from seed %d: %w", lvl, seed, ErrUnsolvable)
+}
+
+// generate places up to the number of objects given by Settings "s", fewer if there is no space left.
+func (g *Generator) generate(seed int64, s difficulty.Settings) (*Layout, error) {
+	w := g.world
+	w.Clear()
+	w.Seed(seed)
+
+	l := &Layout{Seed: seed, W: w.W, H: w.H}
+
+	pos, err := w.Place(HeroID, kind.Hero, g.reach.W, g.reach.H)
+	if err != nil {
+		return nil, fmt.Errorf("could not place hero: %w", err)
+	}
+	l.Hero = Object{ID: HeroID, Bounds: *pos}
+
+	for i := 0; i < s.Rooms; i++ {
+		if err := g.room(i, l); err != nil {
+			break
+		}
+	}
+
+	for i := 0; i < s.Pits; i++ {
+		o, err := g.pit(fmt.Sprintf("pit-%d", i), s)
+		if err != nil {
+			if errors.Is(err, world.ErrNoSpace) {
+				break
+			}
+			return nil, err
+		}
+		l.Pits = append(l.Pits, *o)
+	}
+
+	for i := 0; i < s.Zones; i++ {
+		z, ok := g.zone(fmt.Sprintf("zone-%d", i), s.ZoneSize, l.Pits)
+		if !ok {
+			break
+		}
+		w.AddZone(z)
+		l.Zones = append(l.Zones, z)
+	}
+
+	for i := 0; i < s.Troves; i++ {
+		optional := i >= s.Troves-s.OptionalTroves
+		if err := g.trove(fmt.Sprintf("trove-%d", i), optional, s, l); err != nil {
+			if errors.Is(err, world.ErrNoSpace) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	// health pickups are useless, when every hit is fatal
+	for i := 0; i < s.HealthPickups && s.Health > 0; i++ {
+		id := fmt.Sprintf("pickup-%d", i)
+		pos, err := w.Place(id, kind.Pickup, pickup.Size, pickup.Size)
+		if err != nil {
+			break
+		}
+		l.Pickups = append(l.Pickups, Object{ID: id, Bounds: *pos, Item: item.Health})
+	}
+
+	for i := 0; i < s.Pickups; i++ {
+		id := fmt.Sprintf("item-%d", i)
+		o, err := g.item(s.Items)
+		if err != nil {
+			return nil, err
+		}
+		pos, err := w.Place(id, kind.Pickup, pickup.Size, pickup.Size)
+		if err != nil {
+			break
+		}
+		o.ID, o.Bounds = id, *pos
+		l.Pickups = append(l.Pickups, o)
+	}
+
+	props := EnemyProps(s)
+	for i := 0; i < s.Enemies; i++ {
+		id := fmt.Sprintf("enemy-%d", i)
+		a := g.archetype(s.Archetypes)
+		pos, err := w.Place(id, kind.Enemy, enemySize, enemySize, enemy.SightClearance(a.Props(props)))
+		if err != nil {
+			break
+		}
+		o := Object{ID: id, Bounds: *pos, Archetype: a}
+		if a == enemy.Patroller {
+			o.Route = g.patrolRoute(*pos)
+		}
+		l.Enemies = append(l.Enemies, o)
+	}
+
+	for i := 0; i < s.Hazards; i++ {
+		objs, err := g.hazard(fmt.Sprintf("hazard-%d", i), s)
+		if err != nil {
+			if errors.Is(err, world.ErrNoSpace) {
+				break
+			}
+			return nil, err
+		}
+		l.Hazards = append(l.Hazards, objs...)
+	}
+
+	// exit is placed far from the Hero, if there is space for it
+	pos, err = w.Place(ExitID, kind.Exit, exit.Size, exit.Size, world.Clearance{Kind: kind.Hero, Margin: exitMargin})
+	if err != nil {
+		pos, err = w.Place(ExitID, kind.Exit, exit.Size, exit.Size)
+	}
+	if err == nil {
+		l.Exit = Object{ID: ExitID, Bounds: *pos}
+	}
+
+	return l, nil
+}
+
+// trove places a trove of a kind picked at random by the Settings "s", guarded trove comes along with a guard
+// at one of its sides, both are kept out of sight of the Hero's spawn point. Guarded trove becomes a static one,
+// if there is no space for the guard.
+func (g *Generator) trove(id string, optional bool, s difficulty.Settings, l *Layout) error {
+	w := g.world
+
+	t := treasure.Static
+	if name, ok := g.pick(s.TroveKinds); ok {
+		var err error
+		if t, err = treasure.Parse(name); err != nil {
+			return err
+		}
+	}
+
+	var rules []world.Clearance
+	sight := enemy.SightClearance(enemy.Guard.Props(EnemyProps(s)))
+	if t == treasure.Guarded {
+		rules = append(rules, sight)
+	}
+	pos, err := w.Place(id, kind.Trove, troveSize, troveSize, rules...)
+	if err != nil {
+		return err
+	}
+
+	o := Object{ID: id, Bounds: *pos, Treasure: t, Optional: optional}
+	switch t {
+	case treasure.Fleeting:
+		o.From = w.Rand.Int63n(maxDelay + 1)
+		o.Until = o.From + minWindow + w.Rand.Int63n(maxWindow-minWindow+1)
+	case treasure.Guarded:
+		guard, ok := g.guard(id+"-guard", *pos, sight)
+		if !ok {
+			o.Treasure = treasure.Static
+			break
+		}
+		l.Enemies = append(l.Enemies, guard)
+	}
+	l.Troves = append(l.Troves, o)
+	return nil
+}
+
+// guard places a guard next to one of the sides of the "trove" picked at random, the side must be clear
+// of other objects and keep the "sight" clearance to the Hero.
+func (g *Generator) guard(id string, trove sdl.Rect, sight world.Clearance) (Object, bool) {
+	w := g.world
+	d := int32(guardGap + enemySize)
+	sides := []sdl.Point{
+		{X: trove.X + trove.W + guardGap, Y: trove.Y},
+		{X: trove.X, Y: trove.Y + trove.H + guardGap},
+		{X: trove.X - d, Y: trove.Y},
+		{X: trove.X, Y: trove.Y - d},
+	}
+	first := w.Rand.Intn(len(sides))
+	for i := range sides {
+		p := sides[(first+i)%len(sides)]
+		pos := sdl.Rect{X: p.X, Y: p.Y, W: enemySize, H: enemySize}
+		// the gap keeps the guard off the trove and anything else around
+		if !w.PlaceAt(id, kind.Enemy, pos, world.Clearance{Margin: guardGap / 2}, sight) {
+			continue
+		}
+		return Object{ID: id, Bounds: pos, Archetype: enemy.Guard}, true
+	}
+	return Object{}, false
+}
+
+// room builds a treasure room with a trove inside, a door of random color in one of its walls
+// and a key to the door somewhere outside of any room.
+func (g *Generator) room(i int, l *Layout) error {
+	w := g.world
+	id := fmt.Sprintf("room-%d", i)
+	k := key.Type(w.Rand.Intn(int(key.Yellow) + 1))
+
+	keyPos, err := w.Place(id+"-key", kind.Pickup, pickup.Size, pickup.Size)
+	if err != nil {
+		return err
+	}
+	size := int32(roomSize + 2*wall.Size)
+	pos, err := w.Place(id, kind.Room, size, size)
+	if err != nil {
+		w.Remove(id + "-key")
+		return err
+	}
+	l.Pickups = append(l.Pickups, Object{ID: id + "-key", Bounds: *keyPos, Item: item.Key, Key: k})
+
+	x, y, t := pos.X, pos.Y, int32(wall.Size)
+	sides := []sdl.Rect{
+		{X: x, Y: y, W: size, H: t},
+		{X: x + size - t, Y: y + t, W: t, H: size - 2*t},
+		{X: x, Y: y + size - t, W: size, H: t},
+		{X: x, Y: y + t, W: t, H: size - 2*t},
+	}
+	doorSide := w.Rand.Intn(len(sides))
+	var walls []sdl.Rect
+	for j, side := range sides {
+		if j != doorSide {
+			walls = append(walls, side)
+			continue
+		}
+		// door splits its wall in halves
+		d, a, b := side, side, side
+		if side.W > side.H {
+			d.X, d.W = side.X+(side.W-door.Size)/2, door.Size
+			a.W = d.X - side.X
+			b.X, b.W = d.X+d.W, side.X+side.W-d.X-d.W
+		} else {
+			d.Y, d.H = side.Y+(side.H-door.Size)/2, door.Size
+			a.H = d.Y - side.Y
+			b.Y, b.H = d.Y+d.H, side.Y+side.H-d.Y-d.H
+		}
+		walls = append(walls, a, b)
+		w.Register(id+"-door", kind.Door, &d)
+		l.Doors = append(l.Doors, Object{ID: id + "-door", Bounds: d, Key: k})
+	}
+	for j := range walls {
+		wallID := fmt.Sprintf("%s-wall-%d", id, j)
+		w.Register(wallID, kind.Wall, &walls[j])
+		l.Walls = append(l.Walls, Object{ID: wallID, Bounds: walls[j]})
+	}
+
+	trove := sdl.Rect{X: x + (size-troveSize)/2, Y: y + (size-troveSize)/2, W: troveSize, H: troveSize}
+	w.Register(id+"-trove", kind.Trove, &trove)
+	l.Troves = append(l.Troves, Object{ID: id + "-trove", Bounds: trove})
+
+	return nil
+}
+
+// zone returns a Zone of random terrain with sides up to "size", which keeps a jump away from the "pits",
+// so that the Hero always runs up to and lands after a jump on the ground.
+func (g *Generator) zone(id string, size int32, pits []Object) (world.Zone, bool) {
+	w := g.world
+	size = max(size, minZoneSize)
+	margin := g.reach.Jump + max(g.reach.W, g.reach.H)
+
+	for attempt := 0; attempt < zoneAttempts; attempt++ {
+		width := minZoneSize + w.Rand.Int31n(size-minZoneSize+1)
+		height := minZoneSize + w.Rand.Int31n(size-minZoneSize+1)
+		if width >= w.W || height >= w.H {
+			continue
+		}
+		z := world.Zone{
+			ID:      id,
+			Bounds:  sdl.Rect{X: w.Rand.Int31n(w.W - width), Y: w.Rand.Int31n(w.H - height), W: width, H: height},
+			Terrain: terrain.Type(1 + w.Rand.Intn(int(terrain.Sand))),
+		}
+		if !nearPit(&z.Bounds, pits, margin) {
+			return z, true
+		}
+	}
+	return world.Zone{}, false
+}
+
+// pit places a pit of a kind picked at random by the Settings "s", it returns world.ErrNoSpace
+// if there is no space left.
+// Moving pit reserves the whole area along its path.
+func (g *Generator) pit(id string, s difficulty.Settings) (*Object, error) {
+	w := g.world
+	width := int32(math.Max(minPitSize, float64(w.Rand.Int31n(max(s.PitSize, 1)))))
+	height := int32(math.Max(minPitSize, float64(w.Rand.Int31n(max(s.PitSize, 1)))))
+
+	tr := trap.Static
+	if name, ok := g.pick(s.Traps); ok {
+		var err error
+		if tr, err = trap.Parse(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var travel sdl.Point
+	if tr == trap.Moving {
+		d := minTravel + w.Rand.Int31n(maxTravel-minTravel+1)
+		if w.Rand.Intn(2) == 0 {
+			travel.X = d
+		} else {
+			travel.Y = d
+		}
+	}
+
+	pos, err := w.Place(id, kind.Pit, width+travel.X, height+travel.Y, world.Clearance{Kind: kind.Hero, Margin: heroPitMargin})
+	if err != nil {
+		return nil, err
+	}
+	depth := int8(w.Rand.Int31n(max(int32(s.PitDepth), 1)))
+
+	o := &Object{ID: id, Bounds: sdl.Rect{X: pos.X, Y: pos.Y, W: width, H: height}, Depth: depth, Trap: tr}
+	switch tr {
+	case trap.Moving:
+		o.Path = []sdl.Point{{X: pos.X, Y: pos.Y}, {X: pos.X + travel.X, Y: pos.Y + travel.Y}}
+	case trap.Timed:
+		o.Period = int64(minPeriod + w.Rand.Intn(maxPeriod-minPeriod+1))
+	}
+	return o, nil
+}
+
+// hazard places a hazard of a kind picked at random by the Settings "s", it returns world.ErrNoSpace
+// if there is no space left.
+// Boulder reserves the whole area along its path, an arrow comes along with a plate, which fires it.
+func (g *Generator) hazard(id string, s difficulty.Settings) ([]Object, error) {
+	w := g.world
+
+	t := hazard.Spikes
+	if name, ok := g.pick(s.HazardKinds); ok {
+		var err error
+		if t, err = hazard.Parse(name); err != nil {
+			return nil, err
+		}
+	}
+
+	size := int32(tileSize)
+	switch t {
+	case hazard.Plate, hazard.Arrow:
+		size = plateSize
+	case hazard.Boulder:
+		size = boulderSize
+	}
+
+	var roll sdl.Point
+	if t == hazard.Boulder {
+		d := minRoll + w.Rand.Int31n(maxRoll-minRoll+1)
+		if w.Rand.Intn(2) == 0 {
+			roll.X = d
+		} else {
+			roll.Y = d
+		}
+	}
+
+	pos, err := w.Place(id, kind.Hazard, size+roll.X, size+roll.Y, world.Clearance{Kind: kind.Hero, Margin: heroPitMargin})
+	if err != nil {
+		return nil, err
+	}
+
+	o := Object{ID: id, Bounds: sdl.Rect{X: pos.X, Y: pos.Y, W: size, H: size}, Hazard: t}
+	switch t {
+	case hazard.Spikes, hazard.Fire:
+		o.Period = int64(minPeriod + w.Rand.Intn(maxPeriod-minPeriod+1))
+	case hazard.Boulder:
+		o.Path = []sdl.Point{{X: pos.X, Y: pos.Y}, {X: pos.X + roll.X, Y: pos.Y + roll.Y}}
+	case hazard.Arrow:
+		o.Hazard = hazard.Plate
+		a := g.arrow(id+"-arrow", o.Bounds)
+		o.Trigger = a.ID
+		w.Register(a.ID, kind.Hazard, &a.Bounds)
+		return []Object{o, a}, nil
+	}
+	return []Object{o}, nil
+}
+
+// arrow returns an arrow, which flies across the "plate" from one side to the other within the level.
+func (g *Generator) arrow(id string, plate sdl.Rect) Object {
+	w := g.world
+	c := sdl.Point{X: plate.X + (plate.W-arrowSize)/2, Y: plate.Y + (plate.H-arrowSize)/2}
+	from, to := c, c
+	if w.Rand.Intn(2) == 0 {
+		from.X, to.X = max(0, c.X-arrowRange), min(w.W-arrowSize, c.X+arrowRange)
+	} else {
+		from.Y, to.Y = max(0, c.Y-arrowRange), min(w.H-arrowSize, c.Y+arrowRange)
+	}
+	if w.Rand.Intn(2) == 0 {
+		from, to = to, from
+	}
+	return Object{
+		ID:     id,
+		Bounds: sdl.Rect{X: from.X, Y: from.Y, W: arrowSize, H: arrowSize},
+		Hazard: hazard.Arrow,
+		Path:   []sdl.Point{from, to},
+	}
+}
+
+// area returns an area covered by the Object along its whole path.
+func (o *Object) area() sdl.Rect {
+	a := o.Bounds
+	for _, p := range o.Path {
+		r := sdl.Rect{X: p.X, Y: p.Y, W: o.Bounds.W, H: o.Bounds.H}
+		a = a.Union(&r)
+	}
+	return a
+}
+
+// nearPit tells whether the rectangle "r" is within "margin" of any of the "pits".
+func nearPit(r *sdl.Rect, pits []Object, margin int32) bool {
+	for _, p := range pits {
+		b := p.area()
+		around := sdl.Rect{X: b.X - margin, Y: b.Y - margin, W: b.W + 2*margin, H: b.H + 2*margin}
+		if r.HasIntersection(&around) {
+			return true
+		}
+	}
+	return false
+}
+
+// archetype picks an enemy's Archetype at random by the given "weights", patroller is the default.
+func (g *Generator) archetype(weights map[string]float64) enemy.Archetype {
+	name, ok := g.pick(weights)
+	if !ok {
+		return enemy.Patroller
+	}
+	return enemy.Archetype(name)
+}
+
+// item returns a pickup with an item picked at random by the given "weights", gems get a random value,
+// gem is the default.
+func (g *Generator) item(weights map[string]float64) (Object, error) {
+	it := item.Gem
+	if name, ok := g.pick(weights); ok {
+		var err error
+		if it, err = item.Parse(name); err != nil {
+			return Object{}, err
+		}
+	}
+	o := Object{Item: it}
+	if it == item.Gem {
+		o.Value = gemValues[g.world.Rand.Intn(len(gemValues))]
+	}
+	return o, nil
+}
+
+// pick picks a name at random by the given "weights", it tells false if there is nothing to pick from.
+func (g *Generator) pick(weights map[string]float64) (string, bool) {
+	names := make([]string, 0, len(weights))
+	var total float64
+	for name, v := range weights {
+		names = append(names, name)
+		total += v
+	}
+	if total <= 0 {
+		return "", false
+	}
+	// map iteration order is random, sort names to keep levels reproducible from the seed
+	sort.Strings(names)
+
+	r := g.world.Rand.Float64() * total
+	for _, name := range names {
+		r -= weights[name]
+		if r < 0 {
+			return name, true
+		}
+	}
+	return names[len(names)-1], true
+}
+
+// patrolRoute returns either a square loop or an L-shaped back and forth route, which starts in the center of "pos"
+// and stays within the level, patrollers stop and look around at some of the waypoints.
+func (g *Generator) patrolRoute(pos sdl.Rect) enemy.Route {
+	w := g.world
+	x, y := pos.X+pos.W/2, pos.Y+pos.H/2
+	dx, dy := int32(patrolSize), int32(patrolSize)
+	if x+dx > w.W-pos.W/2 {
+		dx = -dx
+	}
+	if y+dy > w.H-pos.H/2 {
+		dy = -dy
+	}
+
+	route := enemy.Route{PingPong: w.Rand.Intn(2) == 0}
+	points := []sdl.Point{{X: x, Y: y}, {X: x + dx, Y: y}, {X: x + dx, Y: y + dy}}
+	if !route.PingPong {
+		points = append(points, sdl.Point{X: x, Y: y + dy})
+	}
+	for _, p := range points {
+		wp := enemy.Waypoint{X: p.X, Y: p.Y}
+		if w.Rand.Intn(3) == 0 {
+			wp.Wait = patrolWait
+		}
+		route.Waypoints = append(route.Waypoints, wp)
+	}
+	return route
+}
+
+// EnemyProps returns properties of enemies with the given difficulty Settings "s".
+func EnemyProps(s difficulty.Settings) enemy.Props {
+	return enemy.Props{
+		MaxMoveSpeed:  s.EnemySpeed,
+		SightDistance: s.SightDistance,
+		FieldOfView:   s.FieldOfView,
+		TurnRate:      s.TurnRate,
+	}
+}
+
+func max(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
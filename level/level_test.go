@@ -0,0 +1,418 @@
+package level
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/difficulty"
+	"github.com/smeshkov/trovehero/enemy"
+	"github.com/smeshkov/trovehero/hero"
+	"github.com/smeshkov/trovehero/types/hazard"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/key"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/trap"
+	"github.com/smeshkov/trovehero/types/treasure"
+	"github.com/smeshkov/trovehero/world"
+)
+
+var testReach = hero.Reach{W: 50, H: 50, Margin: 10, Jump: 100}
+
+var testSettings = difficulty.Settings{
+	Enemies:       6,
+	EnemySpeed:    2,
+	SightDistance: 150,
+	FieldOfView:   100,
+	TurnRate:      6,
+	Pits:          5,
+	PitSize:       150,
+	PitDepth:      100,
+	Troves:        6,
+	Zones:         4,
+	ZoneSize:      250,
+	Health:        3,
+	HealthPickups: 2,
+}
+
+// ring of pits of the given width around a trove in the middle of 1000x1000 level
+func newRingLayout(width int32) *Layout {
+	return &Layout{
+		W:    1000,
+		H:    1000,
+		Hero: Object{ID: HeroID, Bounds: sdl.Rect{X: 50, Y: 50, W: 50, H: 50}},
+		Pits: []Object{
+			{ID: "pit-0", Bounds: sdl.Rect{X: 300, Y: 300, W: 400, H: width}},
+			{ID: "pit-1", Bounds: sdl.Rect{X: 300, Y: 700 - width, W: 400, H: width}},
+			{ID: "pit-2", Bounds: sdl.Rect{X: 300, Y: 300, W: width, H: 400}},
+			{ID: "pit-3", Bounds: sdl.Rect{X: 700 - width, Y: 300, W: width, H: 400}},
+		},
+		Troves: []Object{{ID: "trove-0", Bounds: sdl.Rect{X: 475, Y: 475, W: 50, H: 50}}},
+	}
+}
+
+func Test_Solvable_no_pits(t *testing.T) {
+	l := newRingLayout(0)
+	l.Pits = nil
+
+	assert.True(t, l.Solvable(testReach))
+}
+
+func Test_Solvable_jump_over(t *testing.T) {
+	l := newRingLayout(40)
+
+	assert.True(t, l.Solvable(testReach))
+}
+
+func Test_Solvable_enclosed(t *testing.T) {
+	l := newRingLayout(150)
+
+	assert.False(t, l.Solvable(testReach))
+}
+
+// room of 170x170 with a red door at the top and a trove inside in the middle of 1000x1000 level
+func newRoomLayout() *Layout {
+	return &Layout{
+		W:    1000,
+		H:    1000,
+		Hero: Object{ID: HeroID, Bounds: sdl.Rect{X: 50, Y: 50, W: 50, H: 50}},
+		Walls: []Object{
+			{ID: "wall-0", Bounds: sdl.Rect{X: 400, Y: 400, W: 50, H: 10}},
+			{ID: "wall-1", Bounds: sdl.Rect{X: 520, Y: 400, W: 50, H: 10}},
+			{ID: "wall-2", Bounds: sdl.Rect{X: 400, Y: 410, W: 10, H: 160}},
+			{ID: "wall-3", Bounds: sdl.Rect{X: 560, Y: 410, W: 10, H: 160}},
+			{ID: "wall-4", Bounds: sdl.Rect{X: 400, Y: 560, W: 170, H: 10}},
+		},
+		Doors:  []Object{{ID: "door-0", Bounds: sdl.Rect{X: 450, Y: 400, W: 70, H: 10}, Key: key.Red}},
+		Troves: []Object{{ID: "trove-0", Bounds: sdl.Rect{X: 460, Y: 460, W: 50, H: 50}}},
+	}
+}
+
+func Test_Solvable_locked_room(t *testing.T) {
+	l := newRoomLayout()
+
+	assert.False(t, l.Solvable(testReach), "no key")
+
+	l.Pickups = []Object{{ID: "key-0", Bounds: sdl.Rect{X: 800, Y: 800, W: 30, H: 30}, Item: item.Key, Key: key.Blue}}
+	assert.False(t, l.Solvable(testReach), "key of another color")
+
+	l.Pickups[0].Key = key.Red
+	assert.True(t, l.Solvable(testReach))
+}
+
+func Test_Solvable_key_behind_pits(t *testing.T) {
+	l := newRoomLayout()
+	l.Pickups = []Object{{ID: "key-0", Bounds: sdl.Rect{X: 900, Y: 900, W: 30, H: 30}, Item: item.Key, Key: key.Red}}
+	l.Pits = []Object{
+		{ID: "pit-0", Bounds: sdl.Rect{X: 750, Y: 750, W: 250, H: 150}},
+		{ID: "pit-1", Bounds: sdl.Rect{X: 750, Y: 850, W: 150, H: 150}},
+	}
+
+	assert.False(t, l.Solvable(testReach))
+}
+
+func Test_Generate_rooms(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Rooms = 2
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.True(t, l.Solvable(testReach))
+	assert.Len(t, l.Doors, 2)
+	assert.Len(t, l.Walls, 10)
+	assert.Len(t, l.Troves, 8)
+	for _, d := range l.Doors {
+		e, ok := w.Entity(d.ID)
+		assert.True(t, ok)
+		assert.Equal(t, kind.Door, e.Kind)
+	}
+}
+
+func Test_Generate(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+
+	l, err := g.Generate(42, 5, testSettings)
+
+	assert.NoError(t, err)
+	assert.True(t, l.Solvable(testReach))
+	assert.Len(t, l.Troves, 6)
+	assert.Len(t, l.Enemies, 6)
+	_, ok := w.Entity(HeroID)
+	assert.True(t, ok)
+}
+
+func Test_Generate_zones_avoid_pits(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+
+	l, err := g.Generate(7, 5, testSettings)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, l.Zones)
+	assert.Equal(t, l.Zones, w.Zones())
+	for _, z := range l.Zones {
+		assert.False(t, nearPit(&z.Bounds, l.Pits, testReach.Jump), z.ID)
+	}
+}
+
+func Test_Generate_classic_has_no_health_pickups(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+
+	l, err := g.Generate(42, 5, testSettings)
+	assert.NoError(t, err)
+	assert.Len(t, l.Pickups, 2)
+
+	s := testSettings
+	s.Health = 0
+	l, err = g.Generate(42, 5, s)
+	assert.NoError(t, err)
+	assert.Empty(t, l.Pickups)
+}
+
+func Test_Generate_items(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.HealthPickups = 0
+	s.Pickups = 5
+	s.Items = map[string]float64{"gem": 1}
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.Len(t, l.Pickups, 5)
+	for _, o := range l.Pickups {
+		assert.Equal(t, item.Gem, o.Item)
+		assert.Contains(t, gemValues, o.Value)
+	}
+}
+
+func Test_Generate_unknown_item(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Pickups = 1
+	s.Items = map[string]float64{"sword": 1}
+
+	_, err := g.Generate(42, 5, s)
+
+	assert.Error(t, err)
+}
+
+func Test_Generate_reproducible(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+
+	l1, err := g.Generate(42, 3, testSettings)
+	assert.NoError(t, err)
+	l2, err := g.Generate(42, 3, testSettings)
+	assert.NoError(t, err)
+
+	assert.Equal(t, l1, l2)
+}
+
+func Test_DefaultReach(t *testing.T) {
+	r := hero.DefaultReach()
+
+	assert.True(t, r.Jump > 0)
+}
+
+func Test_Generate_traps(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Traps = map[string]float64{"moving": 1}
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, l.Pits)
+	for _, p := range l.Pits {
+		assert.Equal(t, trap.Moving, p.Trap)
+		assert.Len(t, p.Path, 2)
+		assert.Equal(t, sdl.Point{X: p.Bounds.X, Y: p.Bounds.Y}, p.Path[0])
+	}
+}
+
+func Test_Solvable_moving_pit_blocks_path(t *testing.T) {
+	l := newRingLayout(0)
+	l.Pits = []Object{{
+		ID:     "pit-0",
+		Bounds: sdl.Rect{X: 0, Y: 300, W: 150, H: 150},
+		Trap:   trap.Moving,
+		Path:   []sdl.Point{{X: 0, Y: 300}, {X: 850, Y: 300}},
+	}}
+
+	assert.False(t, l.Solvable(testReach))
+}
+
+func Test_Generate_hazards(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Hazards = 3
+	s.HazardKinds = map[string]float64{"arrow": 1}
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.Len(t, l.Hazards, 6, "plates come with arrows")
+	for i := 0; i < len(l.Hazards); i += 2 {
+		plate, arrow := l.Hazards[i], l.Hazards[i+1]
+		assert.Equal(t, hazard.Plate, plate.Hazard)
+		assert.Equal(t, hazard.Arrow, arrow.Hazard)
+		assert.Equal(t, arrow.ID, plate.Trigger)
+		assert.Len(t, arrow.Path, 2)
+		e, ok := w.Entity(arrow.ID)
+		assert.True(t, ok)
+		assert.Equal(t, kind.Hazard, e.Kind)
+	}
+}
+
+func Test_Generate_pits_no_space(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Pits = 100
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, l.Pits)
+	assert.Less(t, len(l.Pits), s.Pits, "generator stops, once there is no space left")
+
+	_, err = NewGenerator(world.NewWorld(20, 20, nil, 0, audio.Silent{}), testReach).pit("pit-0", s)
+	assert.True(t, errors.Is(err, world.ErrNoSpace), "pit doesn't fit the world")
+
+	full := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	full.Register("wall", kind.Wall, &sdl.Rect{W: 1280, H: 720})
+	_, err = NewGenerator(full, testReach).pit("pit-0", s)
+	assert.True(t, errors.Is(err, world.ErrNoSpace), "world is full")
+}
+
+func Test_Generate_hazards_no_space(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Hazards = 100
+	s.HazardKinds = map[string]float64{"spikes": 1}
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, l.Hazards)
+	assert.Less(t, len(l.Hazards), s.Hazards, "generator stops, once there is no space left")
+
+	_, err = NewGenerator(world.NewWorld(40, 40, nil, 0, audio.Silent{}), testReach).hazard("hazard-0", s)
+	assert.True(t, errors.Is(err, world.ErrNoSpace))
+}
+
+func Test_Generate_unknown_hazard(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.Hazards = 1
+	s.HazardKinds = map[string]float64{"lava": 1}
+
+	_, err := g.Generate(42, 5, s)
+
+	assert.Error(t, err)
+}
+
+func Test_Generate_guarded_troves(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.TroveKinds = map[string]float64{"guarded": 1}
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	guards := make(map[string]Object)
+	for _, e := range l.Enemies {
+		guards[e.ID] = e
+	}
+	others := append(append(append(append([]Object{l.Hero}, l.Pits...), l.Walls...), l.Hazards...), l.Pickups...)
+	guarded := 0
+	for _, tr := range l.Troves {
+		guard, ok := guards[tr.ID+"-guard"]
+		if tr.Treasure != treasure.Guarded {
+			assert.False(t, ok, "trove without space for a guard is static")
+			continue
+		}
+		guarded++
+		assert.True(t, ok, tr.ID)
+		assert.Equal(t, enemy.Guard, guard.Archetype)
+		assert.True(t, nearPit(&guard.Bounds, []Object{tr}, 2*guardGap), "guard is next to its trove")
+		assert.False(t, nearPit(&guard.Bounds, others, 0), "guard doesn't stand in anything")
+		assert.False(t, nearPit(&guard.Bounds, []Object{l.Hero}, enemy.Guard.Props(EnemyProps(s)).SightDistance), "guard is out of sight")
+	}
+	assert.NotZero(t, guarded)
+}
+
+func Test_PlaceAt_guard(t *testing.T) {
+	w := world.NewWorld(400, 400, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	tr := sdl.Rect{X: 100, Y: 100, W: troveSize, H: troveSize}
+	w.Register("trove", kind.Trove, &tr)
+	// every side is taken, but the one above the trove
+	w.Register("pit", kind.Pit, &sdl.Rect{X: 150, Y: 100, W: 100, H: 100})
+	w.Register("wall", kind.Wall, &sdl.Rect{X: 0, Y: 150, W: 400, H: 20})
+	w.Register("hazard", kind.Hazard, &sdl.Rect{X: 40, Y: 100, W: 20, H: 20})
+
+	guard, ok := g.guard("guard", tr, world.Clearance{Kind: kind.Hero, Margin: 100})
+	assert.True(t, ok)
+	assert.Equal(t, sdl.Rect{X: 100, Y: 100 - guardGap - enemySize, W: enemySize, H: enemySize}, guard.Bounds)
+
+	w.Register("hero", kind.Hero, &sdl.Rect{X: 100, Y: 0, W: 10, H: 10})
+	_, ok = g.guard("guard", tr, world.Clearance{Kind: kind.Hero, Margin: 100})
+	assert.False(t, ok, "every side is blocked")
+}
+
+func Test_Solvable_exit(t *testing.T) {
+	l := newRingLayout(150)
+	l.Troves = nil
+	l.Exit = Object{ID: ExitID, Bounds: sdl.Rect{X: 470, Y: 470, W: 60, H: 60}}
+
+	assert.False(t, l.Solvable(testReach), "exit is enclosed")
+
+	l.Exit.Bounds.X, l.Exit.Bounds.Y = 800, 800
+	assert.True(t, l.Solvable(testReach))
+}
+
+func Test_Solvable_optional_trove(t *testing.T) {
+	l := newRingLayout(150)
+	l.Troves[0].Optional = true
+
+	assert.True(t, l.Solvable(testReach))
+}
+
+func Test_Generate_exit(t *testing.T) {
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	g := NewGenerator(w, testReach)
+	s := testSettings
+	s.OptionalTroves = 2
+
+	l, err := g.Generate(42, 5, s)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExitID, l.Exit.ID)
+	e, ok := w.Entity(ExitID)
+	assert.True(t, ok)
+	assert.Equal(t, kind.Exit, e.Kind)
+	optional := 0
+	for _, tr := range l.Troves {
+		if tr.Optional {
+			optional++
+		}
+	}
+	assert.Equal(t, 2, optional)
+}
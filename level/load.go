@@ -0,0 +1,122 @@
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/types/hazard"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/trap"
+	"github.com/smeshkov/trovehero/world"
+)
+
+// Load loads a handmade Layout with the given "name" from the assets, e.g. "levels/sample.json".
+func Load(a *assets.Manager, name string) (*Layout, error) {
+	data, err := a.Read(fmt.Sprintf("levels/%s.json", name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown level %s: %w", name, err)
+	}
+
+	var l Layout
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("could not parse level %s: %w", name, err)
+	}
+	if err := l.validate(); err != nil {
+		return nil, fmt.Errorf("invalid level %s: %w", name, err)
+	}
+
+	return &l, nil
+}
+
+// validate checks that objects of the Layout fit in it and have everything they need to work.
+func (l *Layout) validate() error {
+	if l.W <= 0 || l.H <= 0 {
+		return fmt.Errorf("size %dx%d", l.W, l.H)
+	}
+	if l.Hero.ID == "" {
+		return fmt.Errorf("no hero")
+	}
+
+	level := sdl.Rect{W: l.W, H: l.H}
+	ids := make(map[string]bool)
+	arrows := make(map[string]bool)
+	for _, o := range l.objects() {
+		if ids[o.ID] {
+			return fmt.Errorf("duplicate ID %s", o.ID)
+		}
+		ids[o.ID] = true
+
+		a := o.area()
+		if o.Bounds.W <= 0 || o.Bounds.H <= 0 || a.Union(&level) != level {
+			return fmt.Errorf("%s is out of the level", o.ID)
+		}
+		if o.Hazard == hazard.Arrow {
+			arrows[o.ID] = true
+		}
+	}
+
+	for _, o := range l.Pits {
+		if o.Trap == trap.Moving && len(o.Path) < 2 {
+			return fmt.Errorf("moving pit %s has no path", o.ID)
+		}
+		if o.Trap == trap.Timed && o.Period <= 0 {
+			return fmt.Errorf("timed pit %s has no period", o.ID)
+		}
+	}
+	for _, o := range l.Hazards {
+		if (o.Hazard == hazard.Arrow || o.Hazard == hazard.Boulder) && len(o.Path) < 2 {
+			return fmt.Errorf("%s %s has no path", o.Hazard, o.ID)
+		}
+		if o.Hazard == hazard.Plate && o.Trigger != "" && !arrows[o.Trigger] {
+			return fmt.Errorf("plate %s triggers unknown arrow %s", o.ID, o.Trigger)
+		}
+	}
+	return nil
+}
+
+// objects returns every object of the Layout.
+func (l *Layout) objects() []Object {
+	objs := []Object{l.Hero}
+	if l.Exit.ID != "" {
+		objs = append(objs, l.Exit)
+	}
+	for _, group := range [][]Object{l.Pits, l.Troves, l.Enemies, l.Pickups, l.Hazards, l.Walls, l.Doors} {
+		objs = append(objs, group...)
+	}
+	return objs
+}
+
+// Register registers objects of the Layout in the World "w" in place of any others, the way Generator does,
+// so that a handmade level can be played.
+func (l *Layout) Register(w *world.World) {
+	w.Clear()
+	w.Seed(l.Seed)
+
+	register := func(objs []Object, k kind.Type) {
+		for _, o := range objs {
+			// moving objects reserve the whole area along their paths, but arrows, which fly over everything
+			a := o.area()
+			if o.Hazard == hazard.Arrow {
+				a = o.Bounds
+			}
+			w.Register(o.ID, k, &a)
+		}
+	}
+	register([]Object{l.Hero}, kind.Hero)
+	if l.Exit.ID != "" {
+		register([]Object{l.Exit}, kind.Exit)
+	}
+	register(l.Pits, kind.Pit)
+	register(l.Troves, kind.Trove)
+	register(l.Enemies, kind.Enemy)
+	register(l.Pickups, kind.Pickup)
+	register(l.Hazards, kind.Hazard)
+	register(l.Walls, kind.Wall)
+	register(l.Doors, kind.Door)
+	for _, z := range l.Zones {
+		w.AddZone(z)
+	}
+}
@@ -0,0 +1,92 @@
+package level
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/enemy"
+	"github.com/smeshkov/trovehero/types/hazard"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/trap"
+	"github.com/smeshkov/trovehero/types/treasure"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Load(t *testing.T) {
+	l, err := Load(assets.NewManager(os.DirFS("../res"), ""), "sample")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1280), l.W)
+	assert.Equal(t, sdl.Rect{X: 60, Y: 330, W: 50, H: 50}, l.Hero.Bounds)
+	assert.Equal(t, trap.Moving, l.Pits[1].Trap)
+	assert.Equal(t, []sdl.Point{{X: 500, Y: 450}, {X: 500, Y: 600}}, l.Pits[1].Path)
+	assert.Equal(t, treasure.Guarded, l.Troves[2].Treasure)
+	assert.True(t, l.Troves[2].Optional)
+	assert.Equal(t, enemy.Patroller, l.Enemies[1].Archetype)
+	assert.True(t, l.Enemies[1].Route.PingPong)
+	assert.Equal(t, hazard.Plate, l.Hazards[1].Hazard)
+	assert.Equal(t, "hazard-1-arrow", l.Hazards[1].Trigger)
+	assert.Equal(t, int64(150), l.Hazards[0].Period)
+	assert.True(t, l.Solvable(testReach))
+
+	// level data survives a round trip
+	data, err := json.Marshal(l)
+	assert.NoError(t, err)
+	var again Layout
+	assert.NoError(t, json.Unmarshal(data, &again))
+	assert.Equal(t, *l, again)
+}
+
+func Test_Load_invalid(t *testing.T) {
+	tests := map[string]string{
+		"no hero":      `{"w": 100, "h": 100}`,
+		"out of level": `{"w": 100, "h": 100, "hero": {"id": "hero", "bounds": {"x": 80, "y": 0, "w": 50, "h": 50}}}`,
+		"no period": `{"w": 500, "h": 500, "hero": {"id": "hero", "bounds": {"w": 50, "h": 50}},
+			"pits": [{"id": "pit-0", "bounds": {"x": 100, "y": 100, "w": 50, "h": 50}, "trap": 2}]}`,
+		"no path": `{"w": 500, "h": 500, "hero": {"id": "hero", "bounds": {"w": 50, "h": 50}},
+			"hazards": [{"id": "hazard-0", "bounds": {"x": 100, "y": 100, "w": 40, "h": 40}, "hazard": 3}]}`,
+		"unknown arrow": `{"w": 500, "h": 500, "hero": {"id": "hero", "bounds": {"w": 50, "h": 50}},
+			"hazards": [{"id": "hazard-0", "bounds": {"x": 100, "y": 100, "w": 30, "h": 30}, "hazard": 1, "trigger": "arrow"}]}`,
+		"duplicate ID": `{"w": 500, "h": 500, "hero": {"id": "hero", "bounds": {"w": 50, "h": 50}},
+			"troves": [{"id": "hero", "bounds": {"x": 100, "y": 100, "w": 50, "h": 50}}]}`,
+		"broken": `{"w": 500,`,
+	}
+	fsys := fstest.MapFS{}
+	for name, data := range tests {
+		fsys["levels/"+name+".json"] = &fstest.MapFile{Data: []byte(data)}
+	}
+	a := assets.NewManager(fsys, "")
+
+	for name := range tests {
+		_, err := Load(a, name)
+		assert.Error(t, err, name)
+	}
+	_, err := Load(a, "missing")
+	assert.Error(t, err)
+}
+
+func Test_Register(t *testing.T) {
+	l, err := Load(assets.NewManager(os.DirFS("../res"), ""), "sample")
+	assert.NoError(t, err)
+	w := world.NewWorld(1280, 720, nil, 0, audio.Silent{})
+	w.Register("stale", kind.Trove, &sdl.Rect{X: 10, Y: 10, W: 10, H: 10})
+
+	l.Register(w)
+
+	_, ok := w.Entity("stale")
+	assert.False(t, ok, "previous level is cleared")
+	e, ok := w.Entity("pit-1")
+	assert.True(t, ok)
+	assert.Equal(t, sdl.Rect{X: 500, Y: 450, W: 60, H: 210}, e.Bounds, "moving pit reserves its path")
+	e, _ = w.Entity("hazard-1-arrow")
+	assert.Equal(t, l.Hazards[2].Bounds, e.Bounds, "arrow flies over everything")
+	assert.Len(t, w.OfKind(kind.Trove), 3)
+	assert.Len(t, w.Zones(), 1)
+}
@@ -0,0 +1,202 @@
+package level
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/hero"
+	"github.com/smeshkov/trovehero/spatial"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/key"
+)
+
+// searchStep is a distance in between positions of the Hero considered by the reachability search.
+const searchStep = 10
+
+// Solvable tells whether Hero with the given Reach "r" can collect every required trove of the Layout
+// and reach the exit, if there is one,
+// Hero walks around pits and jumps over the ones narrower than its jump, walls can't be jumped over,
+// doors open with keys, which Hero can reach. Moving pits block their whole path and pits, which close
+// or crumble, are considered always open. Hazards can be passed in time, so they don't block the way.
+func (l *Layout) Solvable(r hero.Reach) bool {
+	open := make(map[string]bool, len(l.Doors))
+	s := l.search(r, open)
+	for l.openDoors(s, open) {
+		s = l.search(r, open)
+	}
+
+	for _, t := range l.Troves {
+		if !t.Optional && !s.reaches(t.Bounds) {
+			return false
+		}
+	}
+	return l.Exit.ID == "" || s.reaches(l.Exit.Bounds)
+}
+
+// openDoors opens every reachable door, for which a reachable key is left, and tells whether any was opened.
+func (l *Layout) openDoors(s *search, open map[string]bool) bool {
+	keys := make(map[key.Type]int)
+	for _, p := range l.Pickups {
+		if p.Item == item.Key && s.reaches(p.Bounds) {
+			keys[p.Key]++
+		}
+	}
+	for _, d := range l.Doors {
+		if open[d.ID] {
+			keys[d.Key]--
+		}
+	}
+
+	opened := false
+	for _, d := range l.Doors {
+		if open[d.ID] || keys[d.Key] <= 0 {
+			continue
+		}
+		// Hero stands next to a door to open it
+		m := searchStep + s.reach.Margin
+		near := sdl.Rect{X: d.Bounds.X - m, Y: d.Bounds.Y - m, W: d.Bounds.W + 2*m, H: d.Bounds.H + 2*m}
+		if s.reaches(near) {
+			open[d.ID] = true
+			keys[d.Key]--
+			opened = true
+		}
+	}
+	return opened
+}
+
+// search is a grid of the Hero's positions, aligned with its starting position.
+type search struct {
+	reach hero.Reach
+
+	// position of the first cell
+	x0, y0 int32
+
+	cols, rows int32
+
+	free    []bool
+	solid   []bool
+	reached []bool
+}
+
+func (l *Layout) search(r hero.Reach, open map[string]bool) *search {
+	s := &search{
+		reach: r,
+		x0:    l.Hero.Bounds.X % searchStep,
+		y0:    l.Hero.Bounds.Y % searchStep,
+	}
+	s.cols = (l.W-r.W-s.x0)/searchStep + 1
+	s.rows = (l.H-r.H-s.y0)/searchStep + 1
+	if s.cols <= 0 || s.rows <= 0 {
+		return s
+	}
+
+	s.free = make([]bool, s.cols*s.rows)
+	s.solid = make([]bool, s.cols*s.rows)
+	s.reached = make([]bool, s.cols*s.rows)
+
+	for i := range s.free {
+		s.free[i] = true
+	}
+	for _, p := range l.Pits {
+		a := p.area()
+		for i := range s.free {
+			if s.free[i] && s.touches(int32(i), a) {
+				s.free[i] = false
+			}
+		}
+	}
+
+	solids := append([]Object(nil), l.Walls...)
+	for _, d := range l.Doors {
+		if !open[d.ID] {
+			solids = append(solids, d)
+		}
+	}
+	for _, o := range solids {
+		for i := range s.solid {
+			if !s.solid[i] && s.overlaps(int32(i), o.Bounds) {
+				s.solid[i] = true
+				s.free[i] = false
+			}
+		}
+	}
+
+	s.fill(s.index(l.Hero.Bounds.X, l.Hero.Bounds.Y))
+
+	return s
+}
+
+// fill marks all positions reachable from the "start" one.
+func (s *search) fill(start int32) {
+	if start < 0 || !s.free[start] {
+		return
+	}
+
+	jump := s.reach.Jump / searchStep
+	dirs := [4][2]int32{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+	queue := []int32{start}
+	s.reached[start] = true
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		col, row := cur%s.cols, cur/s.cols
+
+		for _, d := range dirs {
+			// the first step is a walk, the rest is a jump
+			for k := int32(1); k <= jump || k == 1; k++ {
+				c, r := col+d[0]*k, row+d[1]*k
+				if c < 0 || c >= s.cols || r < 0 || r >= s.rows {
+					break
+				}
+				next := r*s.cols + c
+				// Hero can't jump over solid objects
+				if s.solid[next] {
+					break
+				}
+				if !s.free[next] || s.reached[next] {
+					continue
+				}
+				s.reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// reaches tells whether Hero can touch the given object from any reachable position.
+func (s *search) reaches(obj sdl.Rect) bool {
+	for i, ok := range s.reached {
+		if ok && s.touches(int32(i), obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// touches tells whether Hero at the position "i" touches the given object, same as Hero does.
+func (s *search) touches(i int32, obj sdl.Rect) bool {
+	x := s.x0 + i%s.cols*searchStep
+	y := s.y0 + i/s.cols*searchStep
+	m := s.reach.Margin
+
+	return obj.X <= x+s.reach.W-m &&
+		obj.X+obj.W-m >= x &&
+		obj.Y <= y+s.reach.H-m &&
+		obj.Y+obj.H-m >= y
+}
+
+// overlaps tells whether Hero at the position "i" overlaps the given solid object.
+func (s *search) overlaps(i int32, obj sdl.Rect) bool {
+	x := s.x0 + i%s.cols*searchStep
+	y := s.y0 + i/s.cols*searchStep
+	return spatial.Overlaps(sdl.Rect{X: x, Y: y, W: s.reach.W, H: s.reach.H}, obj)
+}
+
+func (s *search) index(x, y int32) int32 {
+	col, row := (x-s.x0)/searchStep, (y-s.y0)/searchStep
+	if col < 0 || col >= s.cols || row < 0 || row >= s.rows {
+		return -1
+	}
+	return row*s.cols + col
+}
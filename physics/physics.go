@@ -0,0 +1,70 @@
+// Package physics describes how objects move in the world.
+package physics
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/types/terrain"
+)
+
+// Surface modifies movement on a terrain.
+type Surface struct {
+	// Friction multiplies friction of the ground
+	Friction float64 `json:"friction"`
+	// Speed multiplies speed of objects
+	Speed float64 `json:"speed"`
+}
+
+// Config is a physics shared by all objects in the world.
+type Config struct {
+	Gravity     float32 `json:"gravity"`
+	Friction    float64 `json:"friction"`
+	AirFriction float64 `json:"airFriction"`
+	// AltitudeMargin is the highest altitude objects can be controlled at
+	AltitudeMargin int8 `json:"altitudeMargin"`
+	// CollisionMargin is how deep objects have to overlap to touch
+	CollisionMargin int32 `json:"collisionMargin"`
+
+	// Surfaces by names of terrains, e.g. "ice"
+	Surfaces map[string]Surface `json:"surfaces"`
+}
+
+// DefaultConfig returns default physics.
+func DefaultConfig() Config {
+	return Config{
+		Gravity:         0.1,
+		Friction:        0.08,
+		AirFriction:     0.1,
+		AltitudeMargin:  35,
+		CollisionMargin: 10,
+		Surfaces: map[string]Surface{
+			terrain.Ice.String():  {Friction: 0.15, Speed: 1},
+			terrain.Mud.String():  {Friction: 3, Speed: 0.5},
+			terrain.Sand.String(): {Friction: 1.8, Speed: 0.7},
+		},
+	}
+}
+
+// Surface returns Surface of the given terrain "t", which does not modify movement if it is not configured.
+func (c Config) Surface(t terrain.Type) Surface {
+	if s, ok := c.Surfaces[t.String()]; ok {
+		return s
+	}
+	return Surface{Friction: 1, Speed: 1}
+}
+
+// Load loads Config from the assets "physics.json".
+func Load(a *assets.Manager) (Config, error) {
+	data, err := a.Read("physics.json")
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read physics: %w", err)
+	}
+
+	c := DefaultConfig()
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("could not parse physics: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,28 @@
+package physics
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/types/terrain"
+)
+
+func Test_Surface_ground(t *testing.T) {
+	c := DefaultConfig()
+
+	assert.Equal(t, Surface{Friction: 1, Speed: 1}, c.Surface(terrain.Ground))
+	assert.True(t, c.Surface(terrain.Ice).Friction < 1)
+	assert.True(t, c.Surface(terrain.Mud).Speed < 1)
+}
+
+func Test_Load(t *testing.T) {
+	a := assets.NewManager(os.DirFS("../res"), "")
+
+	c, err := Load(a)
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), c)
+}
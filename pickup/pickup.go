@@ -0,0 +1,123 @@
+package pickup
+
+import (
+	"sync"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/key"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+const (
+	// Size of a side of a Pickup.
+	Size = 30
+)
+
+// Colors of pickups by their items.
+var Colors = map[item.Type]sdl.Color{
+	item.Health:       {R: 230, G: 60, B: 140, A: 255},
+	item.Gem:          {R: 0, G: 200, B: 200, A: 255},
+	item.Speed:        {R: 255, G: 140, B: 0, A: 255},
+	item.Invisibility: {R: 200, G: 200, B: 200, A: 255},
+	item.Shield:       {R: 120, G: 120, B: 255, A: 255},
+	item.Life:         {R: 0, G: 230, B: 90, A: 255},
+	item.Jump:         {R: 170, G: 0, B: 220, A: 255},
+}
+
+// Pickup is an item, which can be picked up by the Hero.
+type Pickup struct {
+	mu sync.RWMutex
+
+	ID string
+
+	// Item Hero gets with the Pickup
+	Item item.Type
+	// Value of a gem
+	Value int
+	// Key is a color of a key
+	Key key.Type
+
+	// position
+	X, Y int32
+	W, H int32
+
+	world *world.World
+
+	collected bool
+}
+
+// NewPickup creates new instance of Pickup with the given "item" of the given "value".
+func NewPickup(id string, x, y int32, it item.Type, value int, w *world.World) *Pickup {
+	return &Pickup{
+		ID:    id,
+		Item:  it,
+		Value: value,
+
+		X: x,
+		Y: y,
+		W: Size,
+		H: Size,
+
+		world: w,
+	}
+}
+
+// NewKey creates new instance of Pickup with a key of the color "k".
+func NewKey(id string, x, y int32, k key.Type, w *world.World) *Pickup {
+	p := NewPickup(id, x, y, item.Key, 0, w)
+	p.Key = k
+	return p
+}
+
+// Paint ...
+func (p *Pickup) Paint(r *sdl.Renderer) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c := Colors[p.Item]
+	if p.Item == item.Key {
+		c = key.Colors[p.Key]
+	}
+	r.SetDrawColor(c.R, c.G, c.B, c.A)
+	r.FillRect(&sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H})
+	// the more valuable a gem is, the more stripes it has
+	if p.Item == item.Gem {
+		r.SetDrawColor(255, 255, 255, 255)
+		for i := int32(1); i < int32(p.Value) && i*6 < p.H; i++ {
+			r.FillRect(&sdl.Rect{X: p.X, Y: p.Y + i*6, W: p.W, H: 2})
+		}
+	}
+	r.SetDrawColor(0, 0, 0, 255)
+
+	return nil
+}
+
+// Restart places Pickup anew.
+func (p *Pickup) Restart() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pos, err := p.world.Place(p.ID, kind.Pickup, Size, Size)
+	if err != nil {
+		return
+	}
+	p.X, p.Y = pos.X, pos.Y
+	p.collected = false
+}
+
+// Collect collects this Pickup.
+func (p *Pickup) Collect() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.collected = true
+}
+
+// IsCollected tells whether Pickup has been collected already or not.
+func (p *Pickup) IsCollected() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.collected
+}
@@ -3,9 +3,23 @@ package pit
 import (
 	"sync"
 
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/hazard"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/trap"
+	"github.com/smeshkov/trovehero/vec"
 	"github.com/smeshkov/trovehero/world"
+)
 
-	"github.com/veandco/go-sdl2/sdl"
+const (
+	// ShallowDepth is a depth of the deepest shallow Pit.
+	ShallowDepth = 30
+
+	// moveSpeed is a speed of moving pits in pixels per tick.
+	moveSpeed = 1
+	// crumbleTicks is a number of ticks a crumbling tile holds after being stepped on.
+	crumbleTicks = 60
 )
 
 // Pit represents an arbitrary pit object in the scene.
@@ -20,21 +34,70 @@ type Pit struct {
 	W, H int32
 
 	depth int8
+	trap  trap.Type
+
+	// path of a moving Pit, positions of its top left corner, which it goes back and forth along
+	path []sdl.Point
+	pos  vec.Vec
+	next int
+	step int
+
+	// period of a timed Pit, it stays open and closed for a period in turn
+	period int64
+
+	// crumbleAt is a tick, when a crumbling tile turns into a pit, zero until it is stepped on
+	crumbleAt int64
 
 	world *world.World
 }
 
-// NewPit creates new instance of the Pit.
+// NewPit creates new instance of the static Pit.
 func NewPit(id string, x, y, width, height int32, depth int8, w *world.World) *Pit {
-	return &Pit{
+	p := &Pit{
 		ID:    id,
-		X:     x,
-		Y:     y,
 		W:     width,
 		H:     height,
 		depth: depth,
+		trap:  trap.Static,
 		world: w,
 	}
+	p.reset(x, y)
+	return p
+}
+
+// NewMovingPit creates new instance of the Pit, which moves along the given "path" of its top left corner.
+func NewMovingPit(id string, width, height int32, depth int8, path []sdl.Point, w *world.World) *Pit {
+	p := NewPit(id, path[0].X, path[0].Y, width, height, depth, w)
+	p.trap = trap.Moving
+	p.path = append([]sdl.Point(nil), path...)
+	return p
+}
+
+// NewTimedPit creates new instance of the Pit, which opens and closes every "period" ticks.
+func NewTimedPit(id string, x, y, width, height int32, depth int8, period int64, w *world.World) *Pit {
+	p := NewPit(id, x, y, width, height, depth, w)
+	p.trap = trap.Timed
+	p.period = period
+	if p.period <= 0 {
+		p.period = hazard.DefaultPeriod
+	}
+	return p
+}
+
+// NewCrumblingPit creates new instance of the floor tile, which turns into the Pit after being stepped on.
+func NewCrumblingPit(id string, x, y, width, height int32, depth int8, w *world.World) *Pit {
+	p := NewPit(id, x, y, width, height, depth, w)
+	p.trap = trap.Crumbling
+	return p
+}
+
+// reset puts the Pit at the given position in its initial state.
+func (p *Pit) reset(x, y int32) {
+	p.time = 0
+	p.X, p.Y = x, y
+	p.pos = vec.Of(sdl.Point{X: x, Y: y})
+	p.next, p.step = 1, 1
+	p.crumbleAt = 0
 }
 
 // Depth tells how deep is the Pit.
@@ -45,12 +108,73 @@ func (p *Pit) Depth() int8 {
 	return p.depth
 }
 
+// IsShallow tells whether the Pit is shallow enough to climb out of it.
+func (p *Pit) IsShallow() bool {
+	return p.depth > -ShallowDepth && p.depth < ShallowDepth
+}
+
+// Bounds returns the current area of the Pit.
+func (p *Pit) Bounds() *sdl.Rect {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return &sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H}
+}
+
+// IsOpen tells whether anything can fall into the Pit right now.
+func (p *Pit) IsOpen() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.isOpen()
+}
+
+func (p *Pit) isOpen() bool {
+	switch p.trap {
+	case trap.Timed:
+		return p.time/p.period%2 == 0
+	case trap.Crumbling:
+		return p.crumbleAt > 0 && p.time >= p.crumbleAt
+	}
+	return true
+}
+
+// Step tells the Pit, that it is stepped on, crumbling tile starts to crumble.
+func (p *Pit) Step() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.trap == trap.Crumbling && p.crumbleAt == 0 {
+		p.crumbleAt = p.time + crumbleTicks
+	}
+}
+
 // Update ...
 func (p *Pit) Update() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	p.time++
+
+	if p.trap == trap.Moving && len(p.path) > 1 {
+		p.move()
+	}
+}
+
+// move moves the Pit towards the next point of its path and turns back at the ends of the path.
+func (p *Pit) move() {
+	to := vec.Of(p.path[p.next]).Sub(p.pos)
+	if to.Len() <= moveSpeed {
+		p.pos = vec.Of(p.path[p.next])
+		if p.next+p.step < 0 || p.next+p.step >= len(p.path) {
+			p.step = -p.step
+		}
+		p.next += p.step
+	} else {
+		p.pos = p.pos.Add(to.Norm().Scale(moveSpeed))
+	}
+
+	pt := p.pos.Point()
+	p.X, p.Y = pt.X, pt.Y
+	p.world.Move(p.ID, &sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H}, 0)
 }
 
 // Paint ...
@@ -58,21 +182,64 @@ func (p *Pit) Paint(r *sdl.Renderer) error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	// fill new rectangle
-	r.SetDrawColor(0, 0, 160, 255)
-	r.FillRect(&sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H})
+	rect := &sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H}
+	switch {
+	case p.isOpen():
+		r.SetDrawColor(0, 0, 160, 255)
+		r.FillRect(rect)
+	case p.trap == trap.Timed:
+		// closed pit shows its outline
+		r.SetDrawColor(0, 0, 160, 255)
+		r.DrawRect(rect)
+	case p.crumbleAt > 0 && p.time/5%2 == 0:
+		// crumbling tile flickers
+		r.SetDrawColor(90, 70, 50, 255)
+		r.FillRect(rect)
+	default:
+		r.SetDrawColor(140, 110, 80, 255)
+		r.FillRect(rect)
+	}
 	r.SetDrawColor(0, 0, 0, 255)
 
 	return nil
 }
 
-// Restart ...
+// area returns an area covered by the Pit along its whole path.
+func (p *Pit) area() sdl.Rect {
+	a := sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H}
+	for _, pt := range p.path {
+		r := sdl.Rect{X: pt.X, Y: pt.Y, W: p.W, H: p.H}
+		a = a.Union(&r)
+	}
+	return a
+}
+
+// Restart places the Pit anew with its path and puts it in the initial state.
 func (p *Pit) Restart() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	pos := p.world.RandomizePos(p.ID, 150, 50)
-	p = NewPit(p.ID, pos.X, pos.Y, pos.W, pos.H, -60, p.world)
+	area := p.area()
+	pos, err := p.world.Place(p.ID, kind.Pit, area.W, area.H)
+	if err != nil {
+		// no space left, Pit restarts where it is
+		p.world.Register(p.ID, kind.Pit, &sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H})
+		p.reset(p.X, p.Y)
+		return
+	}
+
+	// shift the Pit with its path to the new place
+	dx, dy := pos.X-area.X, pos.Y-area.Y
+	for i := range p.path {
+		p.path[i].X += dx
+		p.path[i].Y += dy
+	}
+	x, y := pos.X+p.X-area.X, pos.Y+p.Y-area.Y
+	if len(p.path) > 0 {
+		x, y = p.path[0].X, p.path[0].Y
+	}
+	p.reset(x, y)
+	p.world.Move(p.ID, &sdl.Rect{X: p.X, Y: p.Y, W: p.W, H: p.H}, 0)
 }
 
 // Destroy ...
@@ -0,0 +1,96 @@
+package pit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/hazard"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Update_moving(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	p := NewMovingPit("pit", 50, 50, 100, []sdl.Point{{X: 100, Y: 100}, {X: 200, Y: 100}}, w)
+	w.Register(p.ID, kind.Pit, p.Bounds())
+
+	for i := 0; i < 100; i++ {
+		p.Update()
+	}
+	assert.Equal(t, &sdl.Rect{X: 200, Y: 100, W: 50, H: 50}, p.Bounds())
+	e, _ := w.Entity(p.ID)
+	assert.Equal(t, int32(200), e.Bounds.X)
+
+	for i := 0; i < 50; i++ {
+		p.Update()
+	}
+	assert.Equal(t, int32(150), p.Bounds().X, "goes back")
+}
+
+func Test_IsOpen_timed(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	p := NewTimedPit("pit", 100, 100, 50, 50, 100, 10, w)
+
+	assert.True(t, p.IsOpen())
+	for i := 0; i < 10; i++ {
+		p.Update()
+	}
+	assert.False(t, p.IsOpen())
+	for i := 0; i < 10; i++ {
+		p.Update()
+	}
+	assert.True(t, p.IsOpen())
+}
+
+func Test_IsOpen_timed_no_period(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	p := NewTimedPit("pit-0", 100, 100, 50, 50, 10, 0, w)
+
+	assert.Equal(t, int64(hazard.DefaultPeriod), p.period)
+	assert.True(t, p.IsOpen())
+	for i := 0; i < hazard.DefaultPeriod; i++ {
+		p.Update()
+	}
+	assert.False(t, p.IsOpen(), "default period is used")
+}
+
+func Test_IsOpen_crumbling(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	p := NewCrumblingPit("pit", 100, 100, 50, 50, 100, w)
+
+	for i := 0; i < crumbleTicks*2; i++ {
+		p.Update()
+	}
+	assert.False(t, p.IsOpen(), "holds until stepped on")
+
+	p.Step()
+	for i := 0; i < crumbleTicks-1; i++ {
+		p.Update()
+	}
+	assert.False(t, p.IsOpen())
+	p.Update()
+	assert.True(t, p.IsOpen())
+}
+
+func Test_Restart(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	p := NewMovingPit("pit", 50, 40, 100, []sdl.Point{{X: 100, Y: 100}, {X: 200, Y: 100}}, w)
+	w.Register(p.ID, kind.Pit, p.Bounds())
+	for i := 0; i < 30; i++ {
+		p.Update()
+	}
+
+	p.Restart()
+
+	b := p.Bounds()
+	assert.Equal(t, []sdl.Point{{X: b.X, Y: b.Y}, {X: b.X + 100, Y: b.Y}}, p.path, "path moves along")
+	assert.Equal(t, int32(50), b.W)
+	assert.Equal(t, int32(40), b.H)
+	e, ok := w.Entity(p.ID)
+	assert.True(t, ok)
+	assert.Equal(t, *b, e.Bounds)
+	assert.Equal(t, int64(0), p.time)
+}
@@ -0,0 +1,90 @@
+package scene
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// initialsLen is a number of letters in the player's initials.
+const initialsLen = 3
+
+// initials is an entry of the player's initials for the high-score table after game over,
+// letters are typed or picked with arrows.
+type initials struct {
+	score   int
+	letters []byte
+	cursor  int
+	// skipped tells that the player has refused to enter initials
+	skipped bool
+}
+
+func newInitials(score int) *initials {
+	return &initials{
+		score:   score,
+		letters: []byte(strings.Repeat("A", initialsLen)),
+	}
+}
+
+// handle handles the pressed key and tells whether the entry is over.
+func (in *initials) handle(code sdl.Scancode) bool {
+	switch {
+	case code >= sdl.SCANCODE_A && code <= sdl.SCANCODE_Z:
+		in.letters[in.cursor] = 'A' + byte(code-sdl.SCANCODE_A)
+		in.move(1)
+	case code == sdl.SCANCODE_UP:
+		in.turn(1)
+	case code == sdl.SCANCODE_DOWN:
+		in.turn(-1)
+	case code == sdl.SCANCODE_RIGHT:
+		in.move(1)
+	case code == sdl.SCANCODE_LEFT, code == sdl.SCANCODE_BACKSPACE:
+		in.move(-1)
+	case code == sdl.SCANCODE_RETURN:
+		return true
+	case code == sdl.SCANCODE_ESCAPE:
+		in.skipped = true
+		return true
+	}
+	return false
+}
+
+// move moves the cursor by "n" letters, it stays within the initials.
+func (in *initials) move(n int) {
+	in.cursor += n
+	if in.cursor < 0 {
+		in.cursor = 0
+	}
+	if in.cursor >= len(in.letters) {
+		in.cursor = len(in.letters) - 1
+	}
+}
+
+// turn changes the letter under the cursor by "n" going round the alphabet.
+func (in *initials) turn(n int) {
+	c := (int(in.letters[in.cursor]-'A') + n + 26) % 26
+	in.letters[in.cursor] = 'A' + byte(c)
+}
+
+// String returns the initials entered so far.
+func (in *initials) String() string {
+	return string(in.letters)
+}
+
+// lines returns lines of the entry screen, the letter under the cursor is in brackets.
+func (in *initials) lines() []string {
+	letters := make([]string, len(in.letters))
+	for i, l := range in.letters {
+		letters[i] = string(l)
+		if i == in.cursor {
+			letters[i] = "[" + letters[i] + "]"
+		}
+	}
+	return []string{
+		"New high score",
+		fmt.Sprintf("Score %d", in.score),
+		strings.Join(letters, " "),
+		"Enter to save, Esc to skip",
+	}
+}
@@ -0,0 +1,39 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func Test_initials_typing(t *testing.T) {
+	in := newInitials(100)
+
+	assert.False(t, in.handle(sdl.SCANCODE_A+2))
+	assert.False(t, in.handle(sdl.SCANCODE_Z))
+	assert.False(t, in.handle(sdl.SCANCODE_X))
+	assert.False(t, in.handle(sdl.SCANCODE_D), "cursor stays on the last letter")
+	assert.Equal(t, "CZD", in.String())
+
+	assert.False(t, in.handle(sdl.SCANCODE_BACKSPACE))
+	assert.False(t, in.handle(sdl.SCANCODE_A))
+	assert.Equal(t, "CAD", in.String())
+
+	assert.True(t, in.handle(sdl.SCANCODE_RETURN))
+	assert.False(t, in.skipped)
+}
+
+func Test_initials_arrows(t *testing.T) {
+	in := newInitials(100)
+
+	in.handle(sdl.SCANCODE_DOWN)
+	in.handle(sdl.SCANCODE_RIGHT)
+	in.handle(sdl.SCANCODE_UP)
+	in.handle(sdl.SCANCODE_UP)
+	assert.Equal(t, "ZCA", in.String())
+	assert.Equal(t, "Z [C] A", in.lines()[2])
+
+	assert.True(t, in.handle(sdl.SCANCODE_ESCAPE))
+	assert.True(t, in.skipped)
+}
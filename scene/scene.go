@@ -1,20 +1,57 @@
 package scene
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
 
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/difficulty"
+	"github.com/smeshkov/trovehero/door"
 	"github.com/smeshkov/trovehero/enemy"
+	"github.com/smeshkov/trovehero/exit"
+	"github.com/smeshkov/trovehero/hazard"
 	"github.com/smeshkov/trovehero/hero"
+	"github.com/smeshkov/trovehero/highscore"
+	"github.com/smeshkov/trovehero/level"
+	"github.com/smeshkov/trovehero/physics"
+	"github.com/smeshkov/trovehero/pickup"
 	"github.com/smeshkov/trovehero/pit"
 	"github.com/smeshkov/trovehero/trove"
 	"github.com/smeshkov/trovehero/types/command"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/score"
+	"github.com/smeshkov/trovehero/wall"
 	"github.com/smeshkov/trovehero/world"
 )
 
+const (
+	// tickRate is a duration of a single update of the scene.
+	tickRate = 10 * time.Millisecond
+
+	// touchReach is a distance from the Hero, objects within which are checked for touching the Hero.
+	touchReach = 16
+
+	// parTime is a time to collect a single trove, levels completed faster score a time bonus.
+	parTime = 20 * time.Second
+
+	// scoresTime is a time the high-score table is shown after game over.
+	scoresTime = 3 * time.Second
+
+	// gameTries is a number of times the Hero can die in a game, every death restarts the level until the game is over.
+	gameTries = 3
+)
+
 var (
 	// text colors
 	orangeClr = &sdl.Color{R: 255, G: 100, B: 0, A: 255}
@@ -22,45 +59,149 @@ var (
 	greenClr  = &sdl.Color{R: 0, G: 210, B: 0, A: 255}
 )
 
+// scoreOrder is an order of reasons in the score breakdown.
+var scoreOrder = []score.Type{score.Trove, score.Gem, score.Time, score.Undetected, score.Death}
+
+// layers define painting order of objects at the same altitude.
+var layers = map[kind.Type]int{
+	kind.Pit:    0,
+	kind.Exit:   0,
+	kind.Hazard: 1,
+	kind.Trove:  1,
+	kind.Pickup: 1,
+	kind.Wall:   1,
+	kind.Door:   1,
+	kind.Hero:   2,
+	kind.Enemy:  3,
+}
+
+// painter is a scene object, which can be painted.
+type painter interface {
+	Paint(r *sdl.Renderer) error
+}
+
 // Scene represent the scene of the game.
 type Scene struct {
+	assets  *assets.Manager
 	world   *world.World
 	hero    *hero.Hero
 	pits    []*pit.Pit
 	trove   []*trove.Trove
+	pickups []*pickup.Pickup
+	walls   []*wall.Wall
+	doors   []*door.Door
+	hazards []*hazard.Hazard
 	enemies []*enemy.Enemy
+	// exit finishes the level, the level is won instantly, if there is none
+	exit *exit.Exit
+
+	// behaviour trees of enemies
+	trees enemy.Trees
+	// generates layouts of levels
+	generator *level.Generator
+	// difficulty of the game and its settings at the current level
+	profile  *difficulty.Profile
+	settings difficulty.Settings
+	// number of ticks since the level started
+	ticks int64
+	// stats of the current level
+	stats stats
+	// seed of the game, seeds of levels are derived from it
+	seed int64
+	// custom is a handmade level, which is played instead of generated ones, nil if there is none
+	custom *level.Layout
+	// level, which every game starts at, and number of ticks played since the game started
+	start  int
+	played int64
+	// scores keep the best results, nil if they can't be stored
+	scores *highscore.Store
+	// tries left in the game, the game is over once there are none
+	tries int
+	// naming is an entry of initials after game over, nil while playing
+	naming *initials
+	// quit tells that the game is over because the player quits, the app quits after the entry of initials
+	quit bool
+	// debug shows traces of enemies' behaviour trees, toggled by F3
+	debug bool
+	// font of the debug overlay, nil if it is not loaded
+	font *ttf.Font
+
+	// objects by ID, resolve results of the world queries
+	pitByID    map[string]*pit.Pit
+	troveByID  map[string]*trove.Trove
+	pickupByID map[string]*pickup.Pickup
+	wallByID   map[string]*wall.Wall
+	doorByID   map[string]*door.Door
+	hazardByID map[string]*hazard.Hazard
+	enemyByID  map[string]*enemy.Enemy
 }
 
 // NewScene returns new instance of the Scene.
-func NewScene(r *sdl.Renderer, level int8) (*Scene, error) {
+// Levels are generated from the given "seed" with the given difficulty Profile "p",
+// so that the game can be reproduced, unless a handmade level "custom" is given, which is played over and over.
+// Results of games are kept in "scores", unless it is nil.
+func NewScene(r *sdl.Renderer, a *assets.Manager, player audio.Player, lvl int, seed int64, p *difficulty.Profile, custom *level.Layout, scores *highscore.Store) (*Scene, error) {
 	// bg, err := img.LoadTexture(r, "res/imgs/background.png")
 	// if err != nil {
 	// 	return nil, fmt.Errorf("could not load background image: %w", err)
 	// }
 
 	viewPort := r.GetViewport()
+	s, err := newScene(a, player, &viewPort, lvl, seed, p, custom, scores)
+	if err != nil {
+		return nil, err
+	}
 
-	w := world.NewWorld(viewPort.W, viewPort.H, &viewPort, level)
+	if s.font, err = a.Font("fonts/Flappy.ttf", 10); err != nil {
+		return nil, fmt.Errorf("could not load font: %w", err)
+	}
 
-	// used for storing ID of the object
-	var id string
+	return s, nil
+}
 
-	// used for storing position of the object
-	var pos *sdl.Rect
+// newScene returns new instance of the Scene, which fills the "screen".
+func newScene(a *assets.Manager, player audio.Player, screen *sdl.Rect, lvl int, seed int64, p *difficulty.Profile, custom *level.Layout, scores *highscore.Store) (*Scene, error) {
+	if custom != nil && (custom.W > screen.W || custom.H > screen.H) {
+		return nil, fmt.Errorf("level of size %dx%d doesn't fit the screen", custom.W, custom.H)
+	}
+
+	trees, err := enemy.LoadTrees(a)
+	if err != nil {
+		return nil, fmt.Errorf("could not load enemies: %w", err)
+	}
 
-	id = "hero"
-	pos = w.RandomizePos(id, 50, 50)
-	h := hero.NewHero(id, pos.X, pos.Y, w)
+	phys, err := physics.Load(a)
+	if err != nil {
+		return nil, fmt.Errorf("could not load physics: %w", err)
+	}
+
+	w := world.NewWorld(screen.W, screen.H, screen, lvl, player)
+	w.Physics = phys
+
+	s := &Scene{
+		assets:    a,
+		world:     w,
+		trees:     trees,
+		generator: level.NewGenerator(w, hero.NewReach(phys)),
+		profile:   p,
+		settings:  p.At(lvl),
+		seed:      seed,
+		custom:    custom,
+		start:     lvl,
+		tries:     gameTries,
+		scores:    scores,
+	}
 
-	lvl := w.GetLevel()
+	l, err := s.layout(lvl)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate level: %w", err)
+	}
+	s.hero = hero.NewHero(l.Hero.ID, l.Hero.Bounds.X, l.Hero.Bounds.Y, w)
+	if err := s.load(l); err != nil {
+		return nil, fmt.Errorf("could not load level: %w", err)
+	}
 
-	return &Scene{
-		world:   w,
-		hero:    h,
-		pits:    createPits(w, lvl),
-		trove:   createTroves(w, lvl+1),
-		enemies: createEnemies(w, lvl+1),
-	}, nil
+	return s, nil
 }
 
 // Run runs the Scene.
@@ -69,39 +210,94 @@ func (s *Scene) Run(events <-chan sdl.Event, r *sdl.Renderer) <-chan error {
 
 	go func() {
 		defer close(errc)
-		tick := time.Tick(10 * time.Millisecond)
+		tick := time.Tick(tickRate)
 
-		if err := drawTitle(r, "Trove Hero", orangeClr); err != nil {
+		if err := drawTitle(r, s.assets, "Trove Hero", orangeClr); err != nil {
 			errc <- fmt.Errorf("could not draw title: %w", err)
 		}
-		fmt.Printf("Starting on level %d\n", s.world.GetLevel())
+		fmt.Printf("Starting on level %d with seed %d on %s difficulty\n", s.world.GetLevel(), s.seed, s.profile.Name)
 		time.Sleep(1 * time.Second)
+		s.world.Audio.PlayMusic(s.world.GetLevel())
 
 		for {
 			select {
 			case e := <-events:
+				if s.naming != nil {
+					quit, over := s.handleNaming(e)
+					if quit {
+						drawStats(s.world)
+						return
+					}
+					if over && s.quit {
+						s.record()
+						drawStats(s.world)
+						return
+					}
+					if over {
+						if err := s.newGame(r); err != nil {
+							errc <- err
+							return
+						}
+					}
+					continue
+				}
 				if done := s.handleEvent(e); done {
+					if s.qualifies() {
+						s.naming = newInitials(s.world.GetScore())
+						s.quit = true
+						continue
+					}
 					drawStats(s.world)
 					return
 				}
 			case <-tick:
+				if s.naming != nil {
+					if err := drawSummary(r, s.assets, s.naming.lines(), orangeClr); err != nil {
+						errc <- err
+					}
+					continue
+				}
+
 				s.update()
 
 				if s.hero.IsDead() {
-					if err := drawTitle(r, "Game Over", redClr); err != nil {
-						errc <- err
+					if s.retry() {
+						if err := drawTitle(r, s.assets, fmt.Sprintf("Tries left %d", s.tries), redClr); err != nil {
+							errc <- err
+						}
+						time.Sleep(1 * time.Second)
+						if err := s.restart(); err != nil {
+							errc <- err
+							return
+						}
+					} else {
+						if err := drawTitle(r, s.assets, "Game Over", redClr); err != nil {
+							errc <- err
+						}
+						time.Sleep(1 * time.Second)
+						if s.qualifies() {
+							s.naming = newInitials(s.world.GetScore())
+							continue
+						}
+						if err := s.newGame(r); err != nil {
+							errc <- err
+							return
+						}
 					}
-					time.Sleep(1 * time.Second)
-					s.restart()
 				}
 
-				if len(s.trove) == 0 {
-					if err := drawTitle(r, "You won", greenClr); err != nil {
+				if s.isComplete() {
+					sum := s.finish()
+					if err := drawSummary(r, s.assets, sum, greenClr); err != nil {
 						errc <- err
 					}
-					time.Sleep(1 * time.Second)
+					time.Sleep(2 * time.Second)
 					s.world.IncLevel()
-					s.restart()
+					if err := s.restart(); err != nil {
+						errc <- err
+						return
+					}
+					s.stats.deathsAt = s.hero.Deaths()
 				}
 
 				if err := s.paint(r); err != nil {
@@ -136,6 +332,29 @@ func (s *Scene) handleKeyboardEvent(event *sdl.KeyboardEvent) bool {
 	switch event.Keysym.Scancode {
 	case sdl.SCANCODE_ESCAPE:
 		return true
+	case sdl.SCANCODE_F2:
+		if event.Type == sdl.KEYDOWN {
+			path, err := s.saveSnapshot(".")
+			if err != nil {
+				log.Printf("could not save snapshot: %v", err)
+			} else {
+				log.Printf("snapshot is saved to %s", path)
+			}
+		}
+	case sdl.SCANCODE_F3:
+		if event.Type == sdl.KEYDOWN {
+			s.debug = !s.debug
+		}
+	case sdl.SCANCODE_LSHIFT, sdl.SCANCODE_RSHIFT:
+		s.hero.Sneak(event.Type == sdl.KEYDOWN)
+	case sdl.SCANCODE_Z:
+		if event.Type == sdl.KEYDOWN {
+			s.hero.Do(command.Dash)
+		}
+	case sdl.SCANCODE_X:
+		if event.Type == sdl.KEYDOWN {
+			s.hero.Do(command.GroundPound)
+		}
 	case sdl.SCANCODE_SPACE:
 		s.hero.Do(command.Jump)
 	case sdl.SCANCODE_LEFT:
@@ -150,27 +369,121 @@ func (s *Scene) handleKeyboardEvent(event *sdl.KeyboardEvent) bool {
 	return false
 }
 
+// saveSnapshot writes the state of the World as JSON to a file in the directory "dir" and returns its path,
+// the file is named after the level and tick, e.g. "snapshot-2-340.json".
+func (s *Scene) saveSnapshot(dir string) (string, error) {
+	data, err := json.MarshalIndent(s.world.Snapshot(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not encode snapshot: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("snapshot-%d-%d.json", s.world.GetLevel(), s.ticks))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("could not write snapshot: %w", err)
+	}
+	return path, nil
+}
+
 func (s *Scene) update() {
-	for _, v := range s.pits {
-		s.hero.TouchPit(v)
+	s.ticks++
+	s.played++
+	if left := s.timeLeft(); left != nil && *left <= 0 {
+		s.hero.Die()
+	}
+
+	// only objects in the vicinity of the Hero can touch it,
+	// area is extended by the reach of the Hero, as doors open from a distance
+	loc := s.hero.Location()
+	area := &sdl.Rect{X: loc.X - touchReach, Y: loc.Y - touchReach, W: loc.W + 2*touchReach, H: loc.H + 2*touchReach}
+
+	for _, ent := range s.world.EntitiesIn(area) {
+		switch ent.Kind {
+		case kind.Pit:
+			if p, ok := s.pitByID[ent.ID]; ok {
+				s.hero.TouchPit(p)
+			}
+		case kind.Trove:
+			if t, ok := s.troveByID[ent.ID]; ok {
+				s.hero.TouchTrove(t)
+			}
+		case kind.Pickup:
+			if p, ok := s.pickupByID[ent.ID]; ok {
+				s.hero.TouchPickup(p)
+			}
+		case kind.Door:
+			if d, ok := s.doorByID[ent.ID]; ok {
+				s.hero.TouchDoor(d)
+			}
+		case kind.Hazard:
+			if hz, ok := s.hazardByID[ent.ID]; ok {
+				s.hero.TouchHazard(hz)
+			}
+		case kind.Exit:
+			if s.exit != nil && ent.ID == s.exit.ID {
+				s.hero.TouchExit(s.exit)
+			}
+		case kind.Enemy:
+			if e, ok := s.enemyByID[ent.ID]; ok {
+				e.Touch(s.hero)
+			}
+		}
 	}
 
+	// troves, which have vanished uncollected, are missed
 	i := 0 // output index
 	for _, t := range s.trove {
-		s.hero.TouchTrove(t)
-		if !t.IsCollected() {
+		if !t.IsCollected() && !t.IsGone() {
 			// copy and increment index
 			s.trove[i] = t
 			i++
+		} else {
+			s.stats.collect(t)
+			s.world.Remove(t.ID)
+			delete(s.troveByID, t.ID)
 		}
 	}
 	s.trove = s.trove[:i]
 
+	if s.exit != nil && !s.exit.IsOpen() && s.required() == 0 {
+		s.exit.Unlock()
+	}
+
+	i = 0
+	for _, p := range s.pickups {
+		if !p.IsCollected() {
+			s.pickups[i] = p
+			i++
+		} else {
+			s.world.Remove(p.ID)
+			delete(s.pickupByID, p.ID)
+		}
+	}
+	s.pickups = s.pickups[:i]
+
+	i = 0
+	for _, d := range s.doors {
+		if !d.IsOpen() {
+			s.doors[i] = d
+			i++
+		} else {
+			s.world.Remove(d.ID)
+			delete(s.doorByID, d.ID)
+		}
+	}
+	s.doors = s.doors[:i]
+
 	for _, e := range s.enemies {
-		e.Touch(s.hero)
 		e.Watch(s.hero)
 	}
 
+	// enemies get stunned by hazards too
+	for _, hz := range s.hazards {
+		for _, ent := range s.world.EntitiesIn(hz.Bounds()) {
+			if e, ok := s.enemyByID[ent.ID]; ok && ent.Kind == kind.Enemy {
+				e.TouchHazard(hz)
+			}
+		}
+	}
+
 	s.hero.Update()
 
 	for _, v := range s.enemies {
@@ -180,38 +493,161 @@ func (s *Scene) update() {
 	for _, v := range s.pits {
 		v.Update()
 	}
-}
 
-func (s *Scene) restart() {
-	s.hero.Restart()
+	for _, v := range s.trove {
+		v.Update()
+	}
+
+	if s.exit != nil {
+		s.exit.Update()
+	}
+
+	for _, v := range s.hazards {
+		v.Update()
+	}
+
+	s.world.Update()
+}
 
+func (s *Scene) restart() error {
 	lvl := s.world.GetLevel()
-	s.pits = createPits(s.world, lvl)
-	s.trove = createTroves(s.world, lvl+1)
-	s.enemies = createEnemies(s.world, lvl+1)
+	s.settings = s.profile.At(lvl)
+
+	l, err := s.layout(lvl)
+	if err != nil {
+		return fmt.Errorf("could not generate level: %w", err)
+	}
+	s.hero.Respawn(l.Hero.Bounds.X, l.Hero.Bounds.Y)
+	if err := s.load(l); err != nil {
+		return fmt.Errorf("could not load level: %w", err)
+	}
+
+	s.world.Audio.PlayMusic(lvl)
+
+	return nil
+}
+
+// layout returns the handmade level, if there is one, or generates the level "lvl",
+// objects of the level are registered in the World.
+func (s *Scene) layout(lvl int) (*level.Layout, error) {
+	if s.custom != nil {
+		s.custom.Register(s.world)
+		return s.custom, nil
+	}
+	return s.generator.Generate(s.levelSeed(lvl), lvl, s.settings)
+}
+
+// levelSeed returns a seed of the level "lvl" derived from the seed of the game,
+// so that the level is the same whenever it is restarted. Both are hashed together,
+// so that nearby games don't share levels, e.g. the 3rd level of one and the 2nd of the next.
+func (s *Scene) levelSeed(lvl int) int64 {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, [2]int64{s.seed, int64(lvl)})
+	return int64(h.Sum64())
+}
+
+// load creates objects of the given level Layout.
+func (s *Scene) load(l *level.Layout) error {
+	enemies, err := createEnemies(s.world, l.Enemies, level.EnemyProps(s.settings), s.trees)
+	if err != nil {
+		return err
+	}
+	s.pits = createPits(s.world, l.Pits)
+	s.trove = createTroves(s.world, l.Troves)
+	s.pickups = createPickups(s.world, l.Pickups)
+	s.walls = createWalls(l.Walls)
+	s.doors = createDoors(s.world, l.Doors)
+	s.hazards = createHazards(s.world, l.Hazards)
+	s.enemies = enemies
+	s.exit = createExit(s.world, l.Exit)
+	s.ticks = 0
+	s.stats.reset(l)
+	s.hero.SetMaxHealth(s.settings.Health)
+
+	// map objects of the scene by their IDs
+	s.pitByID = make(map[string]*pit.Pit, len(s.pits))
+	for _, v := range s.pits {
+		s.pitByID[v.ID] = v
+	}
+	s.troveByID = make(map[string]*trove.Trove, len(s.trove))
+	for _, v := range s.trove {
+		s.troveByID[v.ID] = v
+	}
+	s.pickupByID = make(map[string]*pickup.Pickup, len(s.pickups))
+	for _, v := range s.pickups {
+		s.pickupByID[v.ID] = v
+	}
+	s.wallByID = make(map[string]*wall.Wall, len(s.walls))
+	for _, v := range s.walls {
+		s.wallByID[v.ID] = v
+	}
+	s.doorByID = make(map[string]*door.Door, len(s.doors))
+	for _, v := range s.doors {
+		s.doorByID[v.ID] = v
+	}
+	s.hazardByID = make(map[string]*hazard.Hazard, len(s.hazards))
+	for _, v := range s.hazards {
+		s.hazardByID[v.ID] = v
+	}
+	s.enemyByID = make(map[string]*enemy.Enemy, len(s.enemies))
+	for _, v := range s.enemies {
+		s.enemyByID[v.ID] = v
+	}
+
+	return nil
 }
 
 func (s *Scene) paint(r *sdl.Renderer) error {
 	r.Clear()
 
-	for _, v := range s.pits {
-		if err := v.Paint(r); err != nil {
+	if err := drawZones(r, s.world); err != nil {
+		return err
+	}
+
+	// only objects in the view are painted, the lower ones first
+	entities := s.world.EntitiesIn(&sdl.Rect{X: 0, Y: 0, W: s.world.W, H: s.world.H})
+	sort.SliceStable(entities, func(i, j int) bool {
+		if entities[i].Altitude != entities[j].Altitude {
+			return entities[i].Altitude < entities[j].Altitude
+		}
+		return layers[entities[i].Kind] < layers[entities[j].Kind]
+	})
+
+	for _, ent := range entities {
+		p := s.painter(ent)
+		if p == nil {
+			continue
+		}
+		if err := p.Paint(r); err != nil {
 			return err
 		}
 	}
 
-	for _, v := range s.trove {
-		if err := v.Paint(r); err != nil {
+	if left := s.timeLeft(); left != nil {
+		if err := drawTimeLeft(r, s.world, *left, s.settings.TimeLimit); err != nil {
 			return err
 		}
 	}
-
-	if err := s.hero.Paint(r); err != nil {
+	if err := drawAlert(r, s.world); err != nil {
 		return err
 	}
-
-	for _, v := range s.enemies {
-		if err := v.Paint(r); err != nil {
+	if err := drawCombo(r, s.world); err != nil {
+		return err
+	}
+	if err := drawAbilities(r, s.hero.Abilities()); err != nil {
+		return err
+	}
+	if err := drawHealth(r, s.hero); err != nil {
+		return err
+	}
+	if err := drawEffects(r, s.hero); err != nil {
+		return err
+	}
+	if err := drawKeys(r, s.hero); err != nil {
+		return err
+	}
+	if s.debug && s.font != nil {
+		if err := drawTraces(r, s.font, s.enemies); err != nil {
 			return err
 		}
 	}
@@ -220,13 +656,221 @@ func (s *Scene) paint(r *sdl.Renderer) error {
 	return nil
 }
 
+// timeLeft returns time left to complete the level or nil if the level has no time limit.
+func (s *Scene) timeLeft() *time.Duration {
+	if s.settings.TimeLimit <= 0 {
+		return nil
+	}
+	left := s.settings.TimeLimit - time.Duration(s.ticks)*tickRate
+	return &left
+}
+
+// painter resolves the scene object of the given world Entity.
+func (s *Scene) painter(ent world.Entity) painter {
+	switch ent.Kind {
+	case kind.Hero:
+		if ent.ID == s.hero.ID {
+			return s.hero
+		}
+	case kind.Pit:
+		if v, ok := s.pitByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Trove:
+		if v, ok := s.troveByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Pickup:
+		if v, ok := s.pickupByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Wall:
+		if v, ok := s.wallByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Door:
+		if v, ok := s.doorByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Hazard:
+		if v, ok := s.hazardByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Enemy:
+		if v, ok := s.enemyByID[ent.ID]; ok {
+			return v
+		}
+	case kind.Exit:
+		if s.exit != nil && ent.ID == s.exit.ID {
+			return s.exit
+		}
+	}
+	return nil
+}
+
+// required returns a number of troves left, which are required to unlock the exit.
+func (s *Scene) required() int {
+	n := 0
+	for _, t := range s.trove {
+		if !t.Optional {
+			n++
+		}
+	}
+	return n
+}
+
+// isComplete tells whether Hero has finished the level by reaching the open exit,
+// or by collecting every required trove, if the level has no exit.
+func (s *Scene) isComplete() bool {
+	if s.exit == nil {
+		return s.required() == 0
+	}
+	return s.exit.IsReached()
+}
+
+// finish scores bonuses of the completed level and returns its breakdown: time, troves, deaths
+// and changes of the score by their reasons.
+func (s *Scene) finish() []string {
+	t := time.Duration(s.ticks) * tickRate
+	s.world.Finish(t, parTime*time.Duration(s.stats.required+s.stats.optional))
+
+	lines := []string{
+		fmt.Sprintf("Level %d complete", s.world.GetLevel()),
+		fmt.Sprintf("Time %d:%02d", int(t.Minutes()), int(t.Seconds())%60),
+		fmt.Sprintf("Troves %d/%d, bonus %d/%d", s.stats.collected, s.stats.required, s.stats.bonus, s.stats.optional),
+		fmt.Sprintf("Deaths %d", s.hero.Deaths()-s.stats.deathsAt),
+	}
+
+	sums := s.world.ScoreBreakdown()
+	for _, r := range scoreOrder {
+		if sums[r] != 0 {
+			lines = append(lines, fmt.Sprintf("%s %+d", r, sums[r]))
+		}
+	}
+	best := 0
+	for _, e := range s.world.ScoreLog() {
+		if e.Combo > best {
+			best = e.Combo
+		}
+	}
+	if best > 1 {
+		lines = append(lines, fmt.Sprintf("Best combo x%d", best))
+	}
+	return append(lines, fmt.Sprintf("Score %d", s.world.GetScore()))
+}
+
+// handleNaming handles event during the entry of initials, it tells whether the app needs to quit
+// and whether the entry is over.
+func (s *Scene) handleNaming(event sdl.Event) (quit, over bool) {
+	switch e := event.(type) {
+	case *sdl.QuitEvent:
+		return true, false
+	case *sdl.KeyboardEvent:
+		if e.Type == sdl.KEYDOWN {
+			return false, s.naming.handle(e.Keysym.Scancode)
+		}
+	}
+	return false, false
+}
+
+// qualifies tells whether the score of the game makes it into the high-score table.
+func (s *Scene) qualifies() bool {
+	if s.scores == nil {
+		return false
+	}
+	ok, err := s.scores.Qualifies(s.world.GetScore())
+	if err != nil {
+		log.Printf("could not check high scores: %v", err)
+		return false
+	}
+	return ok
+}
+
+// retry takes a try away after the Hero's death and tells whether the level can be restarted,
+// otherwise the game is over.
+func (s *Scene) retry() bool {
+	if s.tries > 0 {
+		s.tries--
+	}
+	return s.tries > 0
+}
+
+// record records the result of the game, which is over, unless the player has refused to enter initials.
+func (s *Scene) record() {
+	if s.naming != nil && !s.naming.skipped {
+		_, err := s.scores.Add(highscore.Entry{
+			Name:     s.naming.String(),
+			Score:    s.world.GetScore(),
+			Level:    s.world.GetLevel(),
+			Seed:     s.seed,
+			Date:     time.Now(),
+			PlayTime: time.Duration(s.played) * tickRate,
+		})
+		if err != nil {
+			log.Printf("could not save high score: %v", err)
+		}
+	}
+	s.naming = nil
+}
+
+// newGame records the result of the game, which is over, shows the high-score table
+// and starts a new game from the starting level.
+func (s *Scene) newGame(r *sdl.Renderer) error {
+	s.record()
+
+	if err := s.drawScores(r); err != nil {
+		return err
+	}
+
+	s.world.NewGame(s.start)
+	s.hero.Reset()
+	s.tries = gameTries
+	s.played = 0
+	if err := s.restart(); err != nil {
+		return err
+	}
+	s.stats.deathsAt = s.hero.Deaths()
+	return nil
+}
+
+// drawScores shows the high-score table for a while, if there is one.
+func (s *Scene) drawScores(r *sdl.Renderer) error {
+	if s.scores == nil {
+		return nil
+	}
+	entries, err := s.scores.Load()
+	if err != nil {
+		log.Printf("could not load high scores: %v", err)
+		return nil
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	lines := []string{"High scores"}
+	for i, e := range entries {
+		lines = append(lines, fmt.Sprintf("%d. %s %d level %d", i+1, e.Name, e.Score, e.Level))
+	}
+	if err := drawSummary(r, s.assets, lines, orangeClr); err != nil {
+		return err
+	}
+	time.Sleep(scoresTime)
+	return nil
+}
+
 // Destroy destroys the scene.
 func (s *Scene) Destroy() {
 	for _, v := range s.pits {
 		v.Destroy()
 	}
+	for _, v := range s.hazards {
+		v.Destroy()
+	}
 	s.hero.Destroy()
 	for _, v := range s.enemies {
 		v.Destroy()
 	}
+	if s.font != nil {
+		s.font.Close()
+	}
 }
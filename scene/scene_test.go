@@ -0,0 +1,147 @@
+package scene
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/difficulty"
+	"github.com/smeshkov/trovehero/level"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func testScene(t *testing.T, lvl int, seed int64) *Scene {
+	a := assets.NewManager(os.DirFS("../res"), "")
+	p, err := difficulty.Load(a, difficulty.Normal)
+	assert.NoError(t, err)
+	s, err := newScene(a, audio.Silent{}, &sdl.Rect{W: 1280, H: 720}, lvl, seed, p, nil, nil)
+	assert.NoError(t, err)
+	return s
+}
+
+// areas returns areas of the Hero, pits and troves of the current level.
+func areas(s *Scene) []sdl.Rect {
+	rects := []sdl.Rect{*s.hero.Location()}
+	for _, p := range s.pits {
+		rects = append(rects, *p.Bounds())
+	}
+	for _, t := range s.trove {
+		rects = append(rects, *t.Bounds())
+	}
+	return rects
+}
+
+func Test_restart_same_level(t *testing.T) {
+	s := testScene(t, 2, 42)
+	first := areas(s)
+
+	s.hero.Die()
+	assert.NoError(t, s.restart())
+	assert.Equal(t, first, areas(s), "level is the same after death")
+
+	assert.NoError(t, s.restart())
+	assert.Equal(t, first, areas(s))
+
+	assert.Equal(t, first, areas(testScene(t, 2, 42)), "game is reproduced from its seed")
+}
+
+func Test_restart_next_level(t *testing.T) {
+	s := testScene(t, 2, 42)
+	first := areas(s)
+
+	s.world.IncLevel()
+	assert.NoError(t, s.restart())
+	assert.NotEqual(t, first, areas(s))
+
+	assert.Equal(t, areas(testScene(t, 3, 42)), areas(s), "level doesn't depend on the levels before it")
+}
+
+func Test_levelSeed(t *testing.T) {
+	seeds := make(map[int64]string)
+	for seed := int64(40); seed < 45; seed++ {
+		for lvl := 1; lvl < 6; lvl++ {
+			s := &Scene{seed: seed}
+			name := fmt.Sprintf("seed %d level %d", seed, lvl)
+			if prev, ok := seeds[s.levelSeed(lvl)]; ok {
+				t.Errorf("%s has the same level seed as %s", name, prev)
+			}
+			seeds[s.levelSeed(lvl)] = name
+		}
+	}
+
+	assert.NotEqual(t, areas(testScene(t, 3, 42)), areas(testScene(t, 2, 43)), "nearby games have different levels")
+}
+
+func Test_restart_custom_level(t *testing.T) {
+	a := assets.NewManager(os.DirFS("../res"), "")
+	p, err := difficulty.Load(a, difficulty.Normal)
+	assert.NoError(t, err)
+	l, err := level.Load(a, "sample")
+	assert.NoError(t, err)
+
+	s, err := newScene(a, audio.Silent{}, &sdl.Rect{W: 1280, H: 720}, 0, 42, p, l, nil)
+	assert.NoError(t, err)
+	assert.Len(t, s.hazards, len(l.Hazards))
+	first := areas(s)
+
+	s.world.IncLevel()
+	assert.NoError(t, s.restart())
+	assert.Equal(t, first, areas(s), "handmade level is played over and over")
+
+	_, err = newScene(a, audio.Silent{}, &sdl.Rect{W: 640, H: 480}, 0, 42, p, l, nil)
+	assert.Error(t, err, "level doesn't fit the screen")
+}
+
+func Test_handleKeyboardEvent_debug(t *testing.T) {
+	s := testScene(t, 1, 42)
+	f3 := func(typ uint32) *sdl.KeyboardEvent {
+		return &sdl.KeyboardEvent{Type: typ, Keysym: sdl.Keysym{Scancode: sdl.SCANCODE_F3}}
+	}
+
+	assert.False(t, s.handleKeyboardEvent(f3(sdl.KEYDOWN)))
+	assert.True(t, s.debug)
+	s.handleKeyboardEvent(f3(sdl.KEYUP))
+	assert.True(t, s.debug)
+	s.handleKeyboardEvent(f3(sdl.KEYDOWN))
+	assert.False(t, s.debug)
+}
+
+func Test_retry(t *testing.T) {
+	s := testScene(t, 2, 42)
+
+	for i := 1; i < gameTries; i++ {
+		assert.True(t, s.retry())
+		assert.Equal(t, gameTries-i, s.tries)
+	}
+	assert.False(t, s.retry())
+	assert.Equal(t, 0, s.tries)
+	assert.False(t, s.retry())
+
+	s.world.IncLevel()
+	assert.NoError(t, s.newGame(nil))
+	assert.Equal(t, gameTries, s.tries)
+	assert.Equal(t, 2, s.world.GetLevel())
+}
+
+func Test_saveSnapshot(t *testing.T) {
+	s := testScene(t, 2, 42)
+	s.ticks = 340
+
+	path, err := s.saveSnapshot(t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, "snapshot-2-340.json", filepath.Base(path))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var snap world.Snapshot
+	assert.NoError(t, json.Unmarshal(data, &snap))
+	assert.Equal(t, s.world.Snapshot(), snap)
+	assert.Equal(t, 2, snap.Level)
+}
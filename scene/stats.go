@@ -0,0 +1,39 @@
+package scene
+
+import (
+	"github.com/smeshkov/trovehero/level"
+	"github.com/smeshkov/trovehero/trove"
+)
+
+// stats are counts of the current level.
+type stats struct {
+	required, optional int
+	collected, bonus   int
+	// deathsAt is a number of the Hero's deaths, when the level was started
+	deathsAt int
+}
+
+// reset starts counting troves of the Layout "l" anew.
+func (st *stats) reset(l *level.Layout) {
+	st.required, st.optional = 0, 0
+	st.collected, st.bonus = 0, 0
+	for _, t := range l.Troves {
+		if t.Optional {
+			st.optional++
+		} else {
+			st.required++
+		}
+	}
+}
+
+// collect counts the Trove "t", if it has been collected rather than missed.
+func (st *stats) collect(t *trove.Trove) {
+	if !t.IsCollected() {
+		return
+	}
+	if t.Optional {
+		st.bonus++
+	} else {
+		st.collected++
+	}
+}
@@ -2,24 +2,41 @@ package scene
 
 import (
 	"fmt"
-	"math"
+	"strings"
+	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
-	ttf "github.com/veandco/go-sdl2/ttf"
+	"github.com/veandco/go-sdl2/ttf"
 
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/door"
 	"github.com/smeshkov/trovehero/enemy"
+	"github.com/smeshkov/trovehero/exit"
+	"github.com/smeshkov/trovehero/hazard"
+	"github.com/smeshkov/trovehero/hero"
+	"github.com/smeshkov/trovehero/level"
+	"github.com/smeshkov/trovehero/pickup"
 	"github.com/smeshkov/trovehero/pit"
 	"github.com/smeshkov/trovehero/trove"
+	"github.com/smeshkov/trovehero/types/ability"
+	"github.com/smeshkov/trovehero/types/alert"
+	hazardtype "github.com/smeshkov/trovehero/types/hazard"
+	"github.com/smeshkov/trovehero/types/item"
+	"github.com/smeshkov/trovehero/types/key"
+	"github.com/smeshkov/trovehero/types/terrain"
+	"github.com/smeshkov/trovehero/types/trap"
+	"github.com/smeshkov/trovehero/types/treasure"
+	"github.com/smeshkov/trovehero/wall"
 	"github.com/smeshkov/trovehero/world"
 )
 
 // drawTitle draws a title with given "text".
-func drawTitle(r *sdl.Renderer, text string, color *sdl.Color) error {
+func drawTitle(r *sdl.Renderer, a *assets.Manager, text string, color *sdl.Color) error {
 	if err := r.Clear(); err != nil {
 		return fmt.Errorf("could not clear renderer: %w", err)
 	}
 
-	f, err := ttf.OpenFont("res/fonts/Flappy.ttf", 10)
+	f, err := a.Font("fonts/Flappy.ttf", 10)
 	if err != nil {
 		return fmt.Errorf("could not load font: %w", err)
 	}
@@ -46,43 +63,368 @@ func drawTitle(r *sdl.Renderer, text string, color *sdl.Color) error {
 	return nil
 }
 
+// drawSummary draws given "lines" one under another, the first one is the largest.
+func drawSummary(r *sdl.Renderer, a *assets.Manager, lines []string, color *sdl.Color) error {
+	if err := r.Clear(); err != nil {
+		return fmt.Errorf("could not clear renderer: %w", err)
+	}
+
+	f, err := a.Font("fonts/Flappy.ttf", 10)
+	if err != nil {
+		return fmt.Errorf("could not load font: %w", err)
+	}
+	defer f.Close()
+
+	vp := r.GetViewport()
+	// the first line takes the top third of the screen, the rest share the remaining space
+	rows := []sdl.Rect{{X: 0, Y: 0, W: vp.W, H: vp.H / 3}}
+	h := vp.H - vp.H/3
+	if len(lines) > 1 {
+		h /= int32(len(lines) - 1)
+	}
+	for i := 1; i < len(lines); i++ {
+		rows = append(rows, sdl.Rect{X: vp.W / 4, Y: vp.H/3 + int32(i-1)*h, W: vp.W / 2, H: h})
+	}
+
+	for i, line := range lines {
+		s, err := f.RenderUTF8Solid(line, *color)
+		if err != nil {
+			return fmt.Errorf("could not render summary: %w", err)
+		}
+		t, err := r.CreateTextureFromSurface(s)
+		s.Free()
+		if err != nil {
+			return fmt.Errorf("could not create texture: %w", err)
+		}
+		err = r.Copy(t, nil, &rows[i])
+		t.Destroy()
+		if err != nil {
+			return fmt.Errorf("could not copy texture: %w", err)
+		}
+	}
+
+	r.Present()
+
+	return nil
+}
+
+// drawTimeLeft draws a bar at the top of the screen, which shrinks as time runs out.
+func drawTimeLeft(r *sdl.Renderer, w *world.World, left, limit time.Duration) error {
+	if left < 0 {
+		left = 0
+	}
+	width := int32(int64(w.W) * int64(left) / int64(limit))
+
+	if err := r.SetDrawColor(210, 210, 210, 255); err != nil {
+		return fmt.Errorf("could not set color: %w", err)
+	}
+	if err := r.FillRect(&sdl.Rect{X: 0, Y: 0, W: width, H: 4}); err != nil {
+		return fmt.Errorf("could not draw time left: %w", err)
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// alertColors are colors of the alert indicator.
+var alertColors = map[alert.Type]sdl.Color{
+	alert.Calm:    {R: 0, G: 160, B: 0, A: 255},
+	alert.Caution: {R: 230, G: 200, B: 0, A: 255},
+	alert.Alarm:   {R: 210, G: 0, B: 0, A: 255},
+}
+
+// drawAlert draws an indicator of the World's alert level in the top right corner of the screen.
+func drawAlert(r *sdl.Renderer, w *world.World) error {
+	c := alertColors[w.Alert().Level]
+	if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+		return fmt.Errorf("could not set color: %w", err)
+	}
+	if err := r.FillRect(&sdl.Rect{X: w.W - 20, Y: 8, W: 12, H: 12}); err != nil {
+		return fmt.Errorf("could not draw alert: %w", err)
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// drawCombo draws an orange square for every step of the current combo under the alert indicator.
+func drawCombo(r *sdl.Renderer, w *world.World) error {
+	if err := r.SetDrawColor(255, 100, 0, 255); err != nil {
+		return fmt.Errorf("could not set color: %w", err)
+	}
+	for i := 0; i < w.Combo(); i++ {
+		if err := r.FillRect(&sdl.Rect{X: w.W - 20, Y: 26 + int32(i)*10, W: 12, H: 6}); err != nil {
+			return fmt.Errorf("could not draw combo: %w", err)
+		}
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// abilityOrder is an order of abilities' indicators on HUD.
+var abilityOrder = []ability.Type{ability.Dash, ability.Sneak, ability.GroundPound}
+
+// drawAbilities draws a stamina bar and indicators of abilities in the top left corner of the screen,
+// indicators are bright when abilities are ready and blue while Hero sneaks.
+func drawAbilities(r *sdl.Renderer, s hero.Status) error {
+	if err := r.SetDrawColor(0, 120, 210, 255); err != nil {
+		return fmt.Errorf("could not set color: %w", err)
+	}
+	if err := r.FillRect(&sdl.Rect{X: 8, Y: 8, W: int32(100 * s.Stamina), H: 6}); err != nil {
+		return fmt.Errorf("could not draw stamina: %w", err)
+	}
+
+	for i, a := range abilityOrder {
+		c := sdl.Color{R: 90, G: 90, B: 90, A: 255}
+		if s.Ready[a] {
+			c = sdl.Color{R: 230, G: 230, B: 230, A: 255}
+		}
+		if a == ability.Sneak && s.Sneaking {
+			c = sdl.Color{R: 0, G: 120, B: 210, A: 255}
+		}
+		if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+			return fmt.Errorf("could not set color: %w", err)
+		}
+		if err := r.FillRect(&sdl.Rect{X: 8 + int32(i)*16, Y: 18, W: 12, H: 12}); err != nil {
+			return fmt.Errorf("could not draw %s: %w", a, err)
+		}
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// drawHealth draws health of the Hero below its abilities followed by its extra lives,
+// no health is drawn when every hit is fatal.
+func drawHealth(r *sdl.Renderer, h *hero.Hero) error {
+	health, max := h.Health()
+	for i := 0; i < max; i++ {
+		if i < health {
+			if err := r.SetDrawColor(210, 0, 0, 255); err != nil {
+				return fmt.Errorf("could not set color: %w", err)
+			}
+		} else if err := r.SetDrawColor(90, 90, 90, 255); err != nil {
+			return fmt.Errorf("could not set color: %w", err)
+		}
+		if err := r.FillRect(&sdl.Rect{X: 8 + int32(i)*16, Y: 34, W: 12, H: 12}); err != nil {
+			return fmt.Errorf("could not draw health: %w", err)
+		}
+	}
+
+	c := pickup.Colors[item.Life]
+	if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+		return fmt.Errorf("could not set color: %w", err)
+	}
+	for i := 0; i < h.Lives(); i++ {
+		if err := r.FillRect(&sdl.Rect{X: 8 + int32(max+i)*16, Y: 34, W: 12, H: 12}); err != nil {
+			return fmt.Errorf("could not draw lives: %w", err)
+		}
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// drawKeys draws keys carried by the Hero below its effects.
+func drawKeys(r *sdl.Renderer, h *hero.Hero) error {
+	inv := h.Inventory()
+	x := int32(8)
+	for k := key.Red; k <= key.Yellow; k++ {
+		c := key.Colors[k]
+		if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+			return fmt.Errorf("could not set color: %w", err)
+		}
+		for n := 0; n < inv.Keys[k]; n++ {
+			if err := r.FillRect(&sdl.Rect{X: x, Y: 90, W: 8, H: 12}); err != nil {
+				return fmt.Errorf("could not draw %s key: %w", k, err)
+			}
+			x += 12
+		}
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// effectOrder is an order of effects' bars on HUD.
+var effectOrder = []item.Type{item.Speed, item.Invisibility, item.Shield, item.Jump}
+
+// drawEffects draws a bar of the item's color for every active effect of the Hero below its health,
+// bars shrink as effects wear off.
+func drawEffects(r *sdl.Renderer, h *hero.Hero) error {
+	left := h.Effects()
+	y := int32(50)
+	for _, it := range effectOrder {
+		ticks, ok := left[it]
+		if !ok {
+			continue
+		}
+		c := pickup.Colors[it]
+		if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+			return fmt.Errorf("could not set color: %w", err)
+		}
+		if err := r.FillRect(&sdl.Rect{X: 8, Y: y, W: int32(ticks / 10), H: 4}); err != nil {
+			return fmt.Errorf("could not draw %s: %w", it, err)
+		}
+		y += 8
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// zoneColors are colors of terrain zones.
+var zoneColors = map[terrain.Type]sdl.Color{
+	terrain.Ice:  {R: 190, G: 230, B: 255, A: 255},
+	terrain.Mud:  {R: 110, G: 75, B: 40, A: 255},
+	terrain.Sand: {R: 230, G: 210, B: 160, A: 255},
+}
+
+// drawZones draws terrain zones of the World beneath all objects.
+func drawZones(r *sdl.Renderer, w *world.World) error {
+	for _, z := range w.Zones() {
+		c, ok := zoneColors[z.Terrain]
+		if !ok {
+			continue
+		}
+		if err := r.SetDrawColor(c.R, c.G, c.B, c.A); err != nil {
+			return fmt.Errorf("could not set color: %w", err)
+		}
+		if err := r.FillRect(&z.Bounds); err != nil {
+			return fmt.Errorf("could not draw zone %s: %w", z.ID, err)
+		}
+	}
+	return r.SetDrawColor(0, 0, 0, 255)
+}
+
+// drawTraces draws nodes of behaviour trees ticked by enemies during the last update next to them.
+func drawTraces(r *sdl.Renderer, f *ttf.Font, enemies []*enemy.Enemy) error {
+	for _, e := range enemies {
+		trace := e.Trace()
+		if len(trace.Steps) == 0 {
+			continue
+		}
+		b := e.Bounds()
+		y := b.Y
+		for _, line := range strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n") {
+			s, err := f.RenderUTF8Solid(line, *orangeClr)
+			if err != nil {
+				return fmt.Errorf("could not render trace: %w", err)
+			}
+			t, err := r.CreateTextureFromSurface(s)
+			rect := &sdl.Rect{X: b.X + b.W + 4, Y: y, W: s.W, H: s.H}
+			s.Free()
+			if err != nil {
+				return fmt.Errorf("could not create texture: %w", err)
+			}
+			err = r.Copy(t, nil, rect)
+			t.Destroy()
+			if err != nil {
+				return fmt.Errorf("could not copy texture: %w", err)
+			}
+			y += rect.H
+		}
+	}
+	return nil
+}
+
 func drawStats(w *world.World) error {
 	fmt.Printf("Your score is %d, you've reached level %d\n",
 		w.GetScore(), w.GetLevel())
 	return nil
 }
 
-func createPits(w *world.World, num int8) []*pit.Pit {
-	items := make([]*pit.Pit, num)
-	var i int8
-	for i = 0; i < num; i++ {
-		id := fmt.Sprintf("pit-%d", i)
-		width := int32(math.Max(30, float64(w.Rand.Int31n(150))))
-		height := int32(math.Max(30, float64(w.Rand.Int31n(150))))
-		pos := w.RandomizePos(id, width, height)
-		items[i] = pit.NewPit(id, pos.X, pos.Y, width, height, int8(w.Rand.Int31n(100)), w)
+func createPits(w *world.World, objs []level.Object) []*pit.Pit {
+	items := make([]*pit.Pit, len(objs))
+	for i, o := range objs {
+		b := o.Bounds
+		switch o.Trap {
+		case trap.Moving:
+			items[i] = pit.NewMovingPit(o.ID, b.W, b.H, o.Depth, o.Path, w)
+		case trap.Timed:
+			items[i] = pit.NewTimedPit(o.ID, b.X, b.Y, b.W, b.H, o.Depth, o.Period, w)
+		case trap.Crumbling:
+			items[i] = pit.NewCrumblingPit(o.ID, b.X, b.Y, b.W, b.H, o.Depth, w)
+		default:
+			items[i] = pit.NewPit(o.ID, b.X, b.Y, b.W, b.H, o.Depth, w)
+		}
+	}
+	return items
+}
+
+func createTroves(w *world.World, objs []level.Object) []*trove.Trove {
+	items := make([]*trove.Trove, len(objs))
+	for i, o := range objs {
+		b := o.Bounds
+		switch o.Treasure {
+		case treasure.Shy:
+			items[i] = trove.NewShyTrove(o.ID, b.X, b.Y, w)
+		case treasure.Fleeting:
+			items[i] = trove.NewFleetingTrove(o.ID, b.X, b.Y, o.From, o.Until, w)
+		case treasure.Heavy:
+			items[i] = trove.NewHeavyTrove(o.ID, b.X, b.Y, w)
+		case treasure.Guarded:
+			items[i] = trove.NewGuardedTrove(o.ID, b.X, b.Y, w)
+		default:
+			items[i] = trove.NewTrove(o.ID, b.X, b.Y, w)
+		}
+		items[i].Optional = o.Optional
+	}
+	return items
+}
+
+func createPickups(w *world.World, objs []level.Object) []*pickup.Pickup {
+	items := make([]*pickup.Pickup, len(objs))
+	for i, o := range objs {
+		if o.Item == item.Key {
+			items[i] = pickup.NewKey(o.ID, o.Bounds.X, o.Bounds.Y, o.Key, w)
+			continue
+		}
+		items[i] = pickup.NewPickup(o.ID, o.Bounds.X, o.Bounds.Y, o.Item, o.Value, w)
 	}
 	return items
 }
 
-func createTroves(w *world.World, num int8) []*trove.Trove {
-	items := make([]*trove.Trove, num)
-	var i int8
-	for i = 0; i < num; i++ {
-		id := fmt.Sprintf("trove-%d", i)
-		pos := w.RandomizePos(id, 50, 50)
-		items[i] = trove.NewTrove(id, pos.X, pos.Y, w)
+func createWalls(objs []level.Object) []*wall.Wall {
+	items := make([]*wall.Wall, len(objs))
+	for i, o := range objs {
+		items[i] = wall.NewWall(o.ID, o.Bounds.X, o.Bounds.Y, o.Bounds.W, o.Bounds.H)
 	}
 	return items
 }
 
-func createEnemies(w *world.World, num int8) []*enemy.Enemy {
-	items := make([]*enemy.Enemy, num)
-	var i int8
-	for i = 0; i < num; i++ {
-		id := fmt.Sprintf("enemy-%d", i)
-		pos := w.RandomizePos(id, 50, 50)
-		items[i] = enemy.NewEnemy(id, pos.X, pos.Y, w)
+func createDoors(w *world.World, objs []level.Object) []*door.Door {
+	items := make([]*door.Door, len(objs))
+	for i, o := range objs {
+		items[i] = door.NewDoor(o.ID, o.Bounds.X, o.Bounds.Y, o.Bounds.W, o.Bounds.H, o.Key, w)
 	}
 	return items
 }
+
+func createHazards(w *world.World, objs []level.Object) []*hazard.Hazard {
+	items := make([]*hazard.Hazard, len(objs))
+	for i, o := range objs {
+		b := o.Bounds
+		switch o.Hazard {
+		case hazardtype.Plate:
+			items[i] = hazard.NewPlate(o.ID, b.X, b.Y, b.W, b.H, o.Trigger, w)
+		case hazardtype.Arrow:
+			items[i] = hazard.NewArrow(o.ID, b.W, b.H, o.Path, w)
+		case hazardtype.Boulder:
+			items[i] = hazard.NewBoulder(o.ID, b.W, b.H, o.Path, w)
+		case hazardtype.Fire:
+			items[i] = hazard.NewFire(o.ID, b.X, b.Y, b.W, b.H, o.Period, w)
+		default:
+			items[i] = hazard.NewSpikes(o.ID, b.X, b.Y, b.W, b.H, o.Period, w)
+		}
+	}
+	hazard.Link(items)
+	return items
+}
+
+// createExit creates the exit of the level, it returns nil, if the level has none.
+func createExit(w *world.World, o level.Object) *exit.Exit {
+	if o.ID == "" {
+		return nil
+	}
+	return exit.NewExit(o.ID, o.Bounds.X, o.Bounds.Y, w)
+}
+
+func createEnemies(w *world.World, objs []level.Object, p enemy.Props, trees enemy.Trees) ([]*enemy.Enemy, error) {
+	items := make([]*enemy.Enemy, len(objs))
+	for i, o := range objs {
+		e, err := trees.Spawn(o.ID, o.Bounds.X, o.Bounds.Y, o.Archetype, p, o.Route, w)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = e
+	}
+	return items, nil
+}
@@ -0,0 +1,182 @@
+// Package spatial provides spatial index for fast lookup of objects by location.
+package spatial
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Grid is a uniform grid (spatial hash), which maps objects' bounds to the cells they cover.
+// Grid is not safe for concurrent use.
+type Grid struct {
+	cell int32
+
+	cells map[cell]map[string]*item
+	items map[string]*item
+
+	// incremented on each query in order to skip items, which cover several cells
+	stamp uint64
+}
+
+type cell struct {
+	x, y int32
+}
+
+type item struct {
+	id     string
+	bounds sdl.Rect
+	stamp  uint64
+}
+
+// NewGrid creates new instance of Grid with the given size of a square cell,
+// cell should be comparable to the typical size of an object.
+func NewGrid(cellSize int32) *Grid {
+	if cellSize <= 0 {
+		cellSize = 1
+	}
+	return &Grid{
+		cell:  cellSize,
+		cells: make(map[cell]map[string]*item),
+		items: make(map[string]*item),
+	}
+}
+
+// Set inserts object with the given "id" or moves it to the new "bounds".
+func (g *Grid) Set(id string, bounds sdl.Rect) {
+	it, ok := g.items[id]
+	if ok {
+		if it.bounds == bounds {
+			return
+		}
+		g.unlink(it)
+		it.bounds = bounds
+	} else {
+		it = &item{id: id, bounds: bounds}
+		g.items[id] = it
+	}
+	g.link(it)
+}
+
+// Remove removes object with the given "id".
+func (g *Grid) Remove(id string) {
+	it, ok := g.items[id]
+	if !ok {
+		return
+	}
+	g.unlink(it)
+	delete(g.items, id)
+}
+
+// Clear removes all objects.
+func (g *Grid) Clear() {
+	g.cells = make(map[cell]map[string]*item)
+	g.items = make(map[string]*item)
+}
+
+// Bounds returns bounds of the object with the given "id".
+func (g *Grid) Bounds(id string) (sdl.Rect, bool) {
+	it, ok := g.items[id]
+	if !ok {
+		return sdl.Rect{}, false
+	}
+	return it.bounds, true
+}
+
+// Len returns number of objects in the Grid.
+func (g *Grid) Len() int {
+	return len(g.items)
+}
+
+// Query returns IDs of all objects, which bounds overlap with the given "area".
+func (g *Grid) Query(area sdl.Rect) []string {
+	var ids []string
+	g.Each(area, func(id string, _ sdl.Rect) bool {
+		ids = append(ids, id)
+		return true
+	})
+	return ids
+}
+
+// Each calls "fn" for every object, which bounds overlap with the given "area",
+// iteration stops as soon as "fn" returns false.
+func (g *Grid) Each(area sdl.Rect, fn func(id string, bounds sdl.Rect) bool) {
+	if area.W <= 0 || area.H <= 0 {
+		return
+	}
+
+	g.stamp++
+
+	x0, y0, x1, y1 := g.span(area)
+	for cx := x0; cx <= x1; cx++ {
+		for cy := y0; cy <= y1; cy++ {
+			for _, it := range g.cells[cell{cx, cy}] {
+				if it.stamp == g.stamp {
+					continue
+				}
+				it.stamp = g.stamp
+
+				if !Overlaps(it.bounds, area) {
+					continue
+				}
+				if !fn(it.id, it.bounds) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *Grid) link(it *item) {
+	x0, y0, x1, y1 := g.span(it.bounds)
+	for cx := x0; cx <= x1; cx++ {
+		for cy := y0; cy <= y1; cy++ {
+			c := cell{cx, cy}
+			items, ok := g.cells[c]
+			if !ok {
+				items = make(map[string]*item)
+				g.cells[c] = items
+			}
+			items[it.id] = it
+		}
+	}
+}
+
+func (g *Grid) unlink(it *item) {
+	x0, y0, x1, y1 := g.span(it.bounds)
+	for cx := x0; cx <= x1; cx++ {
+		for cy := y0; cy <= y1; cy++ {
+			c := cell{cx, cy}
+			items := g.cells[c]
+			delete(items, it.id)
+			if len(items) == 0 {
+				delete(g.cells, c)
+			}
+		}
+	}
+}
+
+// span returns range of cells covered by the given rectangle.
+func (g *Grid) span(r sdl.Rect) (x0, y0, x1, y1 int32) {
+	w, h := r.W, r.H
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return floorDiv(r.X, g.cell), floorDiv(r.Y, g.cell),
+		floorDiv(r.X+w-1, g.cell), floorDiv(r.Y+h-1, g.cell)
+}
+
+// floorDiv divides rounding towards negative infinity, so that negative coordinates map to own cells.
+func floorDiv(a, b int32) int32 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Overlaps tells whether two rectangles have a non empty intersection.
+func Overlaps(a, b sdl.Rect) bool {
+	return a.X < b.X+b.W && b.X < a.X+a.W && a.Y < b.Y+b.H && b.Y < a.Y+a.H
+}
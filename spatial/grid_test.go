@@ -0,0 +1,146 @@
+package spatial
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func query(g *Grid, area sdl.Rect) []string {
+	ids := g.Query(area)
+	sort.Strings(ids)
+	return ids
+}
+
+func Test_Grid_Query(t *testing.T) {
+	g := NewGrid(100)
+	g.Set("hero", sdl.Rect{X: 10, Y: 10, W: 50, H: 50})
+	g.Set("pit", sdl.Rect{X: 80, Y: 80, W: 150, H: 150}) // covers several cells
+	g.Set("trove", sdl.Rect{X: 500, Y: 500, W: 50, H: 50})
+
+	assert.Equal(t, []string{"hero", "pit"}, query(g, sdl.Rect{X: 0, Y: 0, W: 100, H: 100}))
+	assert.Equal(t, []string{"pit"}, query(g, sdl.Rect{X: 150, Y: 150, W: 10, H: 10}))
+	assert.Equal(t, []string{"trove"}, query(g, sdl.Rect{X: 540, Y: 540, W: 100, H: 100}))
+	assert.Empty(t, query(g, sdl.Rect{X: 300, Y: 300, W: 50, H: 50}))
+}
+
+func Test_Grid_Set_moves(t *testing.T) {
+	g := NewGrid(100)
+	g.Set("enemy", sdl.Rect{X: 10, Y: 10, W: 50, H: 50})
+	g.Set("enemy", sdl.Rect{X: 410, Y: 10, W: 50, H: 50})
+
+	assert.Empty(t, query(g, sdl.Rect{X: 0, Y: 0, W: 100, H: 100}))
+	assert.Equal(t, []string{"enemy"}, query(g, sdl.Rect{X: 400, Y: 0, W: 100, H: 100}))
+	assert.Equal(t, 1, g.Len())
+}
+
+func Test_Grid_Remove(t *testing.T) {
+	g := NewGrid(100)
+	g.Set("trove", sdl.Rect{X: 10, Y: 10, W: 50, H: 50})
+	g.Remove("trove")
+
+	_, ok := g.Bounds("trove")
+	assert.False(t, ok)
+	assert.Empty(t, query(g, sdl.Rect{X: 0, Y: 0, W: 100, H: 100}))
+	assert.Empty(t, g.cells)
+}
+
+func Test_Grid_negative(t *testing.T) {
+	g := NewGrid(100)
+	g.Set("enemy", sdl.Rect{X: -60, Y: -60, W: 50, H: 50})
+
+	assert.Equal(t, []string{"enemy"}, query(g, sdl.Rect{X: -20, Y: -20, W: 20, H: 20}))
+	assert.Empty(t, query(g, sdl.Rect{X: 0, Y: 0, W: 20, H: 20}))
+}
+
+func Test_Grid_Each_stops(t *testing.T) {
+	g := NewGrid(100)
+	for i := 0; i < 10; i++ {
+		g.Set(fmt.Sprintf("trove-%d", i), sdl.Rect{X: int32(i * 10), Y: 0, W: 5, H: 5})
+	}
+
+	var n int
+	g.Each(sdl.Rect{X: 0, Y: 0, W: 100, H: 100}, func(string, sdl.Rect) bool {
+		n++
+		return n < 3
+	})
+
+	assert.Equal(t, 3, n)
+}
+
+// benchmarks compare grid against a linear scan, which is what the scene used to do
+
+const (
+	benchW    = 1280
+	benchH    = 720
+	benchSize = 50
+)
+
+func benchObjects(n int) map[string]sdl.Rect {
+	rnd := rand.New(rand.NewSource(1))
+	objs := make(map[string]sdl.Rect, n)
+	for i := 0; i < n; i++ {
+		objs[fmt.Sprintf("obj-%d", i)] = sdl.Rect{
+			X: rnd.Int31n(benchW - benchSize),
+			Y: rnd.Int31n(benchH - benchSize),
+			W: benchSize,
+			H: benchSize,
+		}
+	}
+	return objs
+}
+
+// each object looks for its neighbours, i.e. all pairs collision check
+func benchmarkLinear(b *testing.B, n int) {
+	objs := benchObjects(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, a := range objs {
+			for _, o := range objs {
+				_ = Overlaps(a, o)
+			}
+		}
+	}
+}
+
+func benchmarkGrid(b *testing.B, n int) {
+	objs := benchObjects(n)
+	g := NewGrid(2 * benchSize)
+	for id, r := range objs {
+		g.Set(id, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, a := range objs {
+			g.Each(a, func(string, sdl.Rect) bool { return true })
+		}
+	}
+}
+
+// moving every object once per tick, as entities do
+func benchmarkGridMove(b *testing.B, n int) {
+	objs := benchObjects(n)
+	g := NewGrid(2 * benchSize)
+	for id, r := range objs {
+		g.Set(id, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for id, r := range objs {
+			r.X = (r.X + int32(i%7)) % benchW
+			g.Set(id, r)
+		}
+	}
+}
+
+func Benchmark_Linear_100(b *testing.B)    { benchmarkLinear(b, 100) }
+func Benchmark_Linear_500(b *testing.B)    { benchmarkLinear(b, 500) }
+func Benchmark_Linear_1000(b *testing.B)   { benchmarkLinear(b, 1000) }
+func Benchmark_Grid_100(b *testing.B)      { benchmarkGrid(b, 100) }
+func Benchmark_Grid_500(b *testing.B)      { benchmarkGrid(b, 500) }
+func Benchmark_Grid_1000(b *testing.B)     { benchmarkGrid(b, 1000) }
+func Benchmark_GridMove_1000(b *testing.B) { benchmarkGridMove(b, 1000) }
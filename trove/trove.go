@@ -5,51 +5,225 @@ import (
 
 	"github.com/veandco/go-sdl2/sdl"
 
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/types/treasure"
+	"github.com/smeshkov/trovehero/vec"
 	"github.com/smeshkov/trovehero/world"
 )
 
 const (
 	troveW = 50
 	troveH = 50
+
+	// fleeRadius is a distance to Hero, at which shy troves start to move away.
+	fleeRadius = 120
+	// fleeSpeed is a speed of shy troves, Hero runs faster.
+	fleeSpeed = 1.5
+	// Bonus is added to the value of an optional trove.
+	Bonus = 2
+	// LiftTicks is a number of ticks Hero stands still on a heavy trove to collect it.
+	LiftTicks = 100
+	// fadeTicks is a number of ticks a fleeting trove blinks before it vanishes.
+	fadeTicks = 150
 )
 
+// Values are scores of troves by their kinds, troves, which are harder to collect, are worth more.
+var Values = map[treasure.Type]int{
+	treasure.Static:   1,
+	treasure.Shy:      3,
+	treasure.Fleeting: 3,
+	treasure.Heavy:    2,
+	treasure.Guarded:  3,
+}
+
 // Trove can be collected by the Hero.
 type Trove struct {
 	mu sync.RWMutex
 
-	ID string
+	ID   string
+	Type treasure.Type
+	// Optional Trove isn't required to unlock the exit, it gives a bonus score
+	Optional bool
 
 	time int64
 
 	// position
 	X, Y int32
 	W, H int32
+	pos  vec.Vec
+
+	// window of a fleeting Trove, it is there from the tick "from" till the tick "until"
+	from, until int64
+
+	// lifted is a number of ticks Hero stands still on a heavy Trove, liftedAt is the last one of them
+	lifted   int64
+	liftedAt int64
 
 	world *world.World
 
 	collected bool
 }
 
-// NewTrove creates new instance of Trove.
+// NewTrove creates new instance of the static Trove.
 func NewTrove(id string, x, y int32, w *world.World) *Trove {
 	return &Trove{
-		ID: id,
+		ID:   id,
+		Type: treasure.Static,
 
-		X: x,
-		Y: y,
-		W: troveW,
-		H: troveH,
+		X:   x,
+		Y:   y,
+		W:   troveW,
+		H:   troveH,
+		pos: vec.Of(sdl.Point{X: x, Y: y}),
 
 		world: w,
 	}
 }
 
+// NewShyTrove creates new instance of the Trove, which moves away from the Hero.
+func NewShyTrove(id string, x, y int32, w *world.World) *Trove {
+	t := NewTrove(id, x, y, w)
+	t.Type = treasure.Shy
+	return t
+}
+
+// NewFleetingTrove creates new instance of the Trove, which is there from the tick "from" till the tick "until".
+func NewFleetingTrove(id string, x, y int32, from, until int64, w *world.World) *Trove {
+	t := NewTrove(id, x, y, w)
+	t.Type = treasure.Fleeting
+	t.from, t.until = from, until
+	return t
+}
+
+// NewHeavyTrove creates new instance of the Trove, which Hero collects by standing still on it.
+func NewHeavyTrove(id string, x, y int32, w *world.World) *Trove {
+	t := NewTrove(id, x, y, w)
+	t.Type = treasure.Heavy
+	return t
+}
+
+// NewGuardedTrove creates new instance of the Trove, which is guarded by an enemy nearby.
+func NewGuardedTrove(id string, x, y int32, w *world.World) *Trove {
+	t := NewTrove(id, x, y, w)
+	t.Type = treasure.Guarded
+	return t
+}
+
 // Update ...
 func (t *Trove) Update() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	t.time++
+
+	if t.Type == treasure.Shy && !t.collected {
+		t.flee()
+	}
+}
+
+// flee moves the Trove away from the Hero nearby, it doesn't leave the level, cross walls, pits or hazards,
+// so that it stays reachable.
+func (t *Trove) flee() {
+	center := t.center()
+	h, ok := t.world.Nearest(kind.Hero, center)
+	if !ok {
+		return
+	}
+	away := vec.Of(center).Sub(vec.Of(h.Center()))
+	if away.Len() > fleeRadius || away.IsZero() {
+		return
+	}
+
+	step := away.Norm().Scale(fleeSpeed)
+	// slide along obstacles, if the way is blocked
+	for _, s := range []vec.Vec{step, {X: step.X}, {Y: step.Y}} {
+		next := t.pos.Add(s)
+		p := next.Point()
+		r := &sdl.Rect{X: p.X, Y: p.Y, W: t.W, H: t.H}
+		if t.blocked(r) {
+			continue
+		}
+		t.pos = next
+		t.X, t.Y = p.X, p.Y
+		t.world.Move(t.ID, r, 0)
+		return
+	}
+}
+
+// blocked tells whether the Trove can't move to the given rectangle "r".
+func (t *Trove) blocked(r *sdl.Rect) bool {
+	if r.X < 0 || r.Y < 0 || r.X+r.W > t.world.W || r.Y+r.H > t.world.H {
+		return true
+	}
+	if t.world.Blocked(r) {
+		return true
+	}
+	for _, e := range t.world.EntitiesIn(r) {
+		if e.Kind == kind.Pit || e.Kind == kind.Hazard {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the current area of the Trove.
+func (t *Trove) Bounds() *sdl.Rect {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return &sdl.Rect{X: t.X, Y: t.Y, W: t.W, H: t.H}
+}
+
+func (t *Trove) center() sdl.Point {
+	return sdl.Point{X: t.X + t.W/2, Y: t.Y + t.H/2}
+}
+
+// Value returns a score of the Trove.
+func (t *Trove) Value() int {
+	if t.Optional {
+		return Values[t.Type] + Bonus
+	}
+	return Values[t.Type]
+}
+
+// IsThere tells whether the Trove can be collected right now, fleeting Trove is there only for a while.
+func (t *Trove) IsThere() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.isThere()
+}
+
+func (t *Trove) isThere() bool {
+	if t.Type != treasure.Fleeting {
+		return true
+	}
+	return t.time >= t.from && t.time < t.until
+}
+
+// IsGone tells whether fleeting Trove has vanished uncollected.
+func (t *Trove) IsGone() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.Type == treasure.Fleeting && t.time >= t.until && !t.collected
+}
+
+// Lift tells heavy Trove, that Hero stands on it, either "still" or moving, and tells whether it is lifted,
+// any other Trove is lifted at once.
+func (t *Trove) Lift(still bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Type != treasure.Heavy {
+		return true
+	}
+	// Hero starts over, if it moves or steps off
+	if !still || t.liftedAt != t.time-1 {
+		t.lifted = 0
+	}
+	t.liftedAt = t.time
+	if still {
+		t.lifted++
+	}
+	return t.lifted >= LiftTicks
 }
 
 // Paint ...
@@ -57,21 +231,58 @@ func (t *Trove) Paint(r *sdl.Renderer) error {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
+	if !t.isThere() {
+		return nil
+	}
+	// fleeting Trove blinks before it vanishes
+	if t.Type == treasure.Fleeting && t.until-t.time < fadeTicks && t.time/10%2 == 0 {
+		return nil
+	}
+
+	rect := &sdl.Rect{X: t.X, Y: t.Y, W: t.W, H: t.H}
+
 	// fill new rectangle
 	r.SetDrawColor(160, 160, 0, 255)
-	r.FillRect(&sdl.Rect{X: t.X, Y: t.Y, W: t.W, H: t.H})
+	r.FillRect(rect)
+
+	switch t.Type {
+	case treasure.Heavy:
+		// lifting progress fills from the bottom
+		r.SetDrawColor(90, 90, 0, 255)
+		r.DrawRect(rect)
+		if t.liftedAt == t.time && t.lifted > 0 {
+			h := t.H * int32(t.lifted) / LiftTicks
+			r.SetDrawColor(255, 255, 120, 255)
+			r.FillRect(&sdl.Rect{X: t.X, Y: t.Y + t.H - h, W: t.W, H: h})
+		}
+	case treasure.Guarded:
+		r.SetDrawColor(210, 0, 0, 255)
+		r.DrawRect(rect)
+	case treasure.Shy:
+		r.SetDrawColor(255, 255, 255, 255)
+		r.DrawRect(rect)
+	}
 	r.SetDrawColor(0, 0, 0, 255)
 
 	return nil
 }
 
-// Restart ...
+// Restart places the Trove anew and puts it in the initial state.
 func (t *Trove) Restart() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	pos := t.world.RandomizePos(t.ID, troveW, troveH)
-	t = NewTrove(t.ID, pos.X, pos.Y, t.world)
+	pos, err := t.world.Place(t.ID, kind.Trove, troveW, troveH)
+	if err != nil {
+		// no space left, Trove restarts where it is
+		pos = &sdl.Rect{X: t.X, Y: t.Y, W: t.W, H: t.H}
+		t.world.Register(t.ID, kind.Trove, pos)
+	}
+	t.X, t.Y = pos.X, pos.Y
+	t.pos = vec.Of(sdl.Point{X: pos.X, Y: pos.Y})
+	t.time = 0
+	t.lifted, t.liftedAt = 0, 0
+	t.collected = false
 }
 
 // Destroy ...
@@ -0,0 +1,82 @@
+package trove
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/kind"
+	"github.com/smeshkov/trovehero/world"
+)
+
+func Test_Update_shy(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	tr := NewShyTrove("trove", 500, 500, w)
+	w.Register(tr.ID, kind.Trove, tr.Bounds())
+	w.Register("hero", kind.Hero, &sdl.Rect{X: 400, Y: 500, W: 50, H: 50})
+
+	for i := 0; i < 10; i++ {
+		tr.Update()
+	}
+	assert.Equal(t, int32(515), tr.Bounds().X, "moves away from Hero")
+	assert.Equal(t, int32(500), tr.Bounds().Y)
+
+	w.Register("pit", kind.Pit, &sdl.Rect{X: 566, Y: 400, W: 100, H: 300})
+	for i := 0; i < 100; i++ {
+		tr.Update()
+	}
+	assert.Equal(t, int32(515), tr.Bounds().X, "stops at the pit")
+	e, _ := w.Entity(tr.ID)
+	assert.Equal(t, *tr.Bounds(), e.Bounds)
+}
+
+func Test_IsThere_fleeting(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	tr := NewFleetingTrove("trove", 500, 500, 10, 20, w)
+
+	assert.False(t, tr.IsThere())
+	for i := 0; i < 10; i++ {
+		tr.Update()
+	}
+	assert.True(t, tr.IsThere())
+	assert.False(t, tr.IsGone())
+	for i := 0; i < 10; i++ {
+		tr.Update()
+	}
+	assert.False(t, tr.IsThere())
+	assert.True(t, tr.IsGone())
+}
+
+func Test_Lift_heavy(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	tr := NewHeavyTrove("trove", 500, 500, w)
+
+	for i := 0; i < LiftTicks-1; i++ {
+		assert.False(t, tr.Lift(true))
+		tr.Update()
+	}
+	tr.Update()
+	assert.False(t, tr.Lift(true), "starts over after stepping off")
+
+	for i := 0; i < LiftTicks-1; i++ {
+		tr.Update()
+		tr.Lift(true)
+	}
+	tr.Update()
+	assert.True(t, tr.Lift(true))
+}
+
+func Test_Restart(t *testing.T) {
+	w := world.NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	tr := NewTrove("trove", 0, 0, w)
+	tr.Collect()
+
+	tr.Restart()
+
+	e, ok := w.Entity(tr.ID)
+	assert.True(t, ok)
+	assert.Equal(t, *tr.Bounds(), e.Bounds)
+	assert.False(t, tr.IsCollected())
+}
@@ -1,19 +1,74 @@
 package trovehero
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
+	"log"
 	"runtime"
+	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
 	ttf "github.com/veandco/go-sdl2/ttf"
 
+	"github.com/smeshkov/trovehero/assets"
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/difficulty"
+	"github.com/smeshkov/trovehero/highscore"
+	"github.com/smeshkov/trovehero/level"
 	"github.com/smeshkov/trovehero/scene"
 )
 
+//go:embed res
+var res embed.FS
+
+// Config holds settings of the game.
+type Config struct {
+	// Level is a starting level.
+	Level int
+	// AssetsDir is an optional directory, which overrides embedded assets.
+	AssetsDir string
+	// Audio holds audio settings.
+	Audio audio.Settings
+	// Seed is a seed of the generated levels, random if zero.
+	Seed int64
+	// Difficulty is a name of the difficulty profile, e.g. "normal".
+	Difficulty string
+	// Classic makes every hit fatal regardless of the difficulty.
+	Classic bool
+	// Map is a name of a handmade level in "levels" of the assets, which is played instead of generated ones.
+	Map string
+	// Scores is a path to the high-score table, the user's data directory is used if empty.
+	Scores string
+}
+
 // Run starts the game.
-func Run(level int8) error {
-	err := sdl.Init(sdl.INIT_EVERYTHING)
+func Run(cfg Config) error {
+	embedded, err := fs.Sub(res, "res")
+	if err != nil {
+		return fmt.Errorf("could not load embedded assets: %w", err)
+	}
+	a := assets.NewManager(embedded, cfg.AssetsDir)
+
+	if cfg.Difficulty == "" {
+		cfg.Difficulty = difficulty.Normal
+	}
+	profile, err := difficulty.Load(a, cfg.Difficulty)
 	if err != nil {
+		return fmt.Errorf("could not load difficulty: %w", err)
+	}
+	if cfg.Classic {
+		profile.Health = difficulty.Curve{}
+	}
+
+	var custom *level.Layout
+	if cfg.Map != "" {
+		if custom, err = level.Load(a, cfg.Map); err != nil {
+			return fmt.Errorf("could not load level: %w", err)
+		}
+	}
+
+	if err := sdl.Init(sdl.INIT_EVERYTHING); err != nil {
 		return fmt.Errorf("could not initialize SDL: %w", err)
 	}
 	defer sdl.Quit()
@@ -29,7 +84,15 @@ func Run(level int8) error {
 	}
 	defer w.Destroy()
 
-	s, err := scene.NewScene(r, level)
+	player := newPlayer(a, cfg.Audio)
+	defer player.Destroy()
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UTC().UnixNano()
+	}
+
+	s, err := scene.NewScene(r, a, player, cfg.Level, seed, profile, custom, newScores(cfg.Scores))
 	if err != nil {
 		return fmt.Errorf("could not create scene: %w", err)
 	}
@@ -47,3 +110,29 @@ func Run(level int8) error {
 		}
 	}
 }
+
+// newScores creates the high-score table, game isn't recorded if there is no place for it.
+func newScores(path string) *highscore.Store {
+	if path != "" {
+		return highscore.NewStore(path)
+	}
+	path, err := highscore.DefaultPath()
+	if err != nil {
+		log.Printf("high scores are disabled: %v", err)
+		return nil
+	}
+	return highscore.NewStore(path)
+}
+
+// newPlayer creates audio player, game stays silent if audio is muted or not available.
+func newPlayer(a *assets.Manager, s audio.Settings) audio.Player {
+	if s.Mute {
+		return audio.Silent{}
+	}
+	m, err := audio.NewMixer(a, s)
+	if err != nil {
+		log.Printf("audio is disabled: %v", err)
+		return audio.Silent{}
+	}
+	return m
+}
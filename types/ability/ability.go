@@ -0,0 +1,28 @@
+package ability
+
+const (
+	// Dash is a short burst of speed.
+	Dash Type = iota
+	// Sneak is a slow, silent and less visible move.
+	Sneak
+	// GroundPound stuns enemies around Hero.
+	GroundPound
+)
+
+var (
+	typeNames = map[Type]string{
+		Dash:        "Dash",
+		Sneak:       "Sneak",
+		GroundPound: "GroundPound",
+	}
+)
+
+// Type is a type of an ability of Hero.
+type Type byte
+
+func (t Type) String() string {
+	if t > GroundPound {
+		return "Unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,28 @@
+package alert
+
+const (
+	// Calm means nobody is looking for Hero.
+	Calm Type = iota
+	// Caution means Hero was seen a while ago.
+	Caution
+	// Alarm means Hero has just been seen.
+	Alarm
+)
+
+var (
+	typeNames = map[Type]string{
+		Calm:    "Calm",
+		Caution: "Caution",
+		Alarm:   "Alarm",
+	}
+)
+
+// Type is an alert level of the World.
+type Type byte
+
+func (t Type) String() string {
+	if t > Alarm {
+		return "Unknown"
+	}
+	return typeNames[t]
+}
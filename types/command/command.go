@@ -19,16 +19,22 @@ const (
 	Jump
 	// Shoot makes Hero to shoot.
 	Shoot
+	// Dash makes Hero to dash in the direction of movement.
+	Dash
+	// GroundPound makes Hero to slam the ground and stun enemies around.
+	GroundPound
 )
 
 var (
 	typeNames = map[Type]string{
-		GoNorth: "GoNorth",
-		GoEast:  "GoEast",
-		GoSouth: "GoSouth",
-		GoWest:  "GoWest",
-		Jump:    "Jump",
-		Shoot:   "Shoot",
+		GoNorth:     "GoNorth",
+		GoEast:      "GoEast",
+		GoSouth:     "GoSouth",
+		GoWest:      "GoWest",
+		Jump:        "Jump",
+		Shoot:       "Shoot",
+		Dash:        "Dash",
+		GroundPound: "GroundPound",
 	}
 )
 
@@ -51,7 +57,7 @@ func ToCommand(d direction.Type) (Type, error) {
 }
 
 func (t Type) String() string {
-	if t < GoNorth || t > Shoot {
+	if t < GoNorth || t > GroundPound {
 		return "Unknown"
 	}
 	return typeNames[t]
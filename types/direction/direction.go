@@ -1,5 +1,7 @@
 package direction
 
+import "math"
+
 const (
 	// North direction.
 	North Type = iota
@@ -29,3 +31,26 @@ func (t Type) String() string {
 	}
 	return typeNames[t]
 }
+
+// Angle returns angle of the direction in radians, clockwise from East, as y axis points down on the screen.
+func (t Type) Angle() float64 {
+	switch t {
+	case North:
+		return -math.Pi / 2
+	case South:
+		return math.Pi / 2
+	case West:
+		return math.Pi
+	}
+	return 0
+}
+
+// Of returns the direction, which is the closest to the given angle "a" in radians.
+func Of(a float64) Type {
+	// quarters clockwise from East
+	q := int(math.Round(a/(math.Pi/2))) % 4
+	if q < 0 {
+		q += 4
+	}
+	return [4]Type{East, South, West, North}[q]
+}
@@ -0,0 +1,51 @@
+package hazard
+
+import "fmt"
+
+const (
+	// Spikes come out of the floor and hide back in turn.
+	Spikes Type = iota
+	// Plate raises the alarm or fires arrows, when stepped on.
+	Plate
+	// Arrow flies along a line, when its plate is stepped on.
+	Arrow
+	// Boulder rolls along a line over and over again.
+	Boulder
+	// Fire burns on a floor tile and dies down in turn.
+	Fire
+)
+
+var (
+	typeNames = map[Type]string{
+		Spikes:  "spikes",
+		Plate:   "plate",
+		Arrow:   "arrow",
+		Boulder: "boulder",
+		Fire:    "fire",
+	}
+)
+
+// Type is a type of a hazard.
+type Type byte
+
+// Parse returns Type of a hazard with the given "name", e.g. "spikes".
+func Parse(name string) (Type, error) {
+	for t, n := range typeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown hazard %s", name)
+}
+
+// IsGround tells whether the hazard is on the ground, so it can be jumped over.
+func (t Type) IsGround() bool {
+	return t != Arrow
+}
+
+func (t Type) String() string {
+	if t > Fire {
+		return "unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,55 @@
+package item
+
+import "fmt"
+
+const (
+	// Health restores health of Hero.
+	Health Type = iota
+	// Gem adds its value to the score.
+	Gem
+	// Speed makes Hero faster for a while.
+	Speed
+	// Invisibility hides Hero from enemies for a while.
+	Invisibility
+	// Shield absorbs the next hit.
+	Shield
+	// Life saves Hero once from death.
+	Life
+	// Jump makes Hero to jump higher for a while.
+	Jump
+	// Key opens a door of its color.
+	Key
+)
+
+var (
+	typeNames = map[Type]string{
+		Health:       "health",
+		Gem:          "gem",
+		Speed:        "speed",
+		Invisibility: "invisibility",
+		Shield:       "shield",
+		Life:         "life",
+		Jump:         "jump",
+		Key:          "key",
+	}
+)
+
+// Type is a type of an item, which can be picked up by Hero.
+type Type byte
+
+// Parse returns Type of an item with the given "name", e.g. "gem".
+func Parse(name string) (Type, error) {
+	for t, n := range typeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown item %s", name)
+}
+
+func (t Type) String() string {
+	if t > Key {
+		return "unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,37 @@
+package key
+
+import "github.com/veandco/go-sdl2/sdl"
+
+const (
+	// Red key.
+	Red Type = iota
+	// Blue key.
+	Blue
+	// Yellow key.
+	Yellow
+)
+
+var (
+	typeNames = map[Type]string{
+		Red:    "red",
+		Blue:   "blue",
+		Yellow: "yellow",
+	}
+
+	// Colors of keys and doors they open.
+	Colors = map[Type]sdl.Color{
+		Red:    {R: 220, G: 40, B: 40, A: 255},
+		Blue:   {R: 40, G: 90, B: 230, A: 255},
+		Yellow: {R: 240, G: 220, B: 40, A: 255},
+	}
+)
+
+// Type is a color of a key and of doors it opens.
+type Type byte
+
+func (t Type) String() string {
+	if t > Yellow {
+		return "unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,57 @@
+package kind
+
+const (
+	// Unknown kind of object.
+	Unknown Type = iota
+	// Hero is a playable character.
+	Hero
+	// Enemy attacks Hero.
+	Enemy
+	// Pit can be jumped over by Hero.
+	Pit
+	// Trove can be collected by Hero.
+	Trove
+	// Pickup can be picked up by Hero.
+	Pickup
+	// Wall blocks the way.
+	Wall
+	// Door blocks the way, until it is opened with a key.
+	Door
+	// Room is an area enclosed by walls.
+	Room
+	// Hazard hurts whoever touches it.
+	Hazard
+	// Exit finishes the level.
+	Exit
+)
+
+var (
+	typeNames = map[Type]string{
+		Unknown: "Unknown",
+		Hero:    "Hero",
+		Enemy:   "Enemy",
+		Pit:     "Pit",
+		Trove:   "Trove",
+		Pickup:  "Pickup",
+		Wall:    "Wall",
+		Door:    "Door",
+		Room:    "Room",
+		Hazard:  "Hazard",
+		Exit:    "Exit",
+	}
+)
+
+// Type is a kind of an object in the world.
+type Type byte
+
+// IsSolid tells whether objects of the kind block the way.
+func (t Type) IsSolid() bool {
+	return t == Wall || t == Door
+}
+
+func (t Type) String() string {
+	if t > Exit {
+		return "Unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,34 @@
+package score
+
+const (
+	// Trove is scored for collecting a trove.
+	Trove Type = iota
+	// Gem is scored for picking up a gem.
+	Gem
+	// Time is a bonus for completing a level quickly.
+	Time
+	// Undetected is a bonus for completing a level unseen by enemies.
+	Undetected
+	// Death is a penalty for losing a life.
+	Death
+)
+
+var (
+	typeNames = map[Type]string{
+		Trove:      "Troves",
+		Gem:        "Gems",
+		Time:       "Time bonus",
+		Undetected: "Undetected",
+		Death:      "Deaths",
+	}
+)
+
+// Type is a reason of a score change.
+type Type byte
+
+func (t Type) String() string {
+	if t > Death {
+		return "Unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,127 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// arcSteps is a number of segments an arc of a Sector is approximated with.
+const arcSteps = 16
+
+// Sector represents a circular sector, e.g. a vision cone.
+// Angles are in radians and go clockwise from East, as y axis points down on the screen.
+type Sector struct {
+	Apex sdl.Point
+	// Angle is a direction of the bisector of the Sector
+	Angle float64
+	// Width is an angle between the sides of the Sector
+	Width  float64
+	Radius int32
+}
+
+// ContainsPoint returns true if the given Point is inside the Sector.
+func (s *Sector) ContainsPoint(p *sdl.Point) bool {
+	dx, dy := float64(p.X-s.Apex.X), float64(p.Y-s.Apex.Y)
+	if dx*dx+dy*dy > float64(s.Radius)*float64(s.Radius) {
+		return false
+	}
+	if dx == 0 && dy == 0 {
+		return true
+	}
+	return math.Abs(Angle(math.Atan2(dy, dx)-s.Angle)) <= s.Width/2
+}
+
+// OverlapsRect returns true if the given Rect overlaps with the Sector.
+func (s *Sector) OverlapsRect(rect *sdl.Rect) bool {
+	apex := s.Apex
+	if apex.InRect(rect) {
+		return true
+	}
+
+	corners := []sdl.Point{
+		{X: rect.X, Y: rect.Y},
+		{X: rect.X + rect.W, Y: rect.Y},
+		{X: rect.X + rect.W, Y: rect.Y + rect.H},
+		{X: rect.X, Y: rect.Y + rect.H},
+	}
+	for i := range corners {
+		if s.ContainsPoint(&corners[i]) {
+			return true
+		}
+	}
+
+	// sides and arc of the Sector
+	arc := s.arc()
+	for i := 0; i < len(arc); i++ {
+		a := apex
+		if i > 0 {
+			a = arc[i-1]
+		}
+		b := arc[i]
+		if rect.IntersectLine(&a.X, &a.Y, &b.X, &b.Y) {
+			return true
+		}
+	}
+	last := arc[len(arc)-1]
+	return rect.IntersectLine(&last.X, &last.Y, &apex.X, &apex.Y)
+}
+
+// Bounds returns bounding box of the Sector.
+func (s *Sector) Bounds() *sdl.Rect {
+	// extreme points of the circle within the Sector
+	points := s.arc()
+	for _, a := range []float64{0, math.Pi / 2, math.Pi, -math.Pi / 2} {
+		if math.Abs(Angle(a-s.Angle)) < s.Width/2 {
+			points = append(points, s.pointAt(a))
+		}
+	}
+
+	minX, minY := s.Apex.X, s.Apex.Y
+	maxX, maxY := minX, minY
+	for _, p := range points {
+		if p.X < minX {
+			minX = p.X
+		}
+		if p.X > maxX {
+			maxX = p.X
+		}
+		if p.Y < minY {
+			minY = p.Y
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+	return &sdl.Rect{X: minX, Y: minY, W: maxX - minX + 1, H: maxY - minY + 1}
+}
+
+// arc returns points of the arc of the Sector in order from one side to the other.
+func (s *Sector) arc() []sdl.Point {
+	from := s.Angle - s.Width/2
+	points := make([]sdl.Point, 0, arcSteps+1)
+	for i := 0; i <= arcSteps; i++ {
+		points = append(points, s.pointAt(from+s.Width*float64(i)/arcSteps))
+	}
+	return points
+}
+
+// pointAt returns a point of the arc of the Sector at the given angle.
+func (s *Sector) pointAt(a float64) sdl.Point {
+	return sdl.Point{
+		X: s.Apex.X + int32(math.Round(float64(s.Radius)*math.Cos(a))),
+		Y: s.Apex.Y + int32(math.Round(float64(s.Radius)*math.Sin(a))),
+	}
+}
+
+// Angle normalizes the given angle "a" in radians to (-Pi, Pi].
+func Angle(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a <= -math.Pi {
+		a += 2 * math.Pi
+	}
+	if a > math.Pi {
+		a -= 2 * math.Pi
+	}
+	return a
+}
@@ -0,0 +1,51 @@
+package shape
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// testSector looks South-East from the origin
+var testSector = &Sector{Apex: sdl.Point{X: 100, Y: 100}, Angle: math.Pi / 4, Width: math.Pi / 2, Radius: 100}
+
+func Test_Sector_ContainsPoint(t *testing.T) {
+	assert.True(t, testSector.ContainsPoint(&sdl.Point{X: 150, Y: 150}))
+	assert.True(t, testSector.ContainsPoint(&sdl.Point{X: 190, Y: 100}))
+	assert.False(t, testSector.ContainsPoint(&sdl.Point{X: 190, Y: 190}), "out of range")
+	assert.False(t, testSector.ContainsPoint(&sdl.Point{X: 50, Y: 150}), "out of the cone")
+}
+
+func Test_Sector_OverlapsRect(t *testing.T) {
+	tests := []struct {
+		name string
+		rect sdl.Rect
+		want bool
+	}{
+		{"inside", sdl.Rect{X: 130, Y: 130, W: 10, H: 10}, true},
+		{"contains apex", sdl.Rect{X: 90, Y: 90, W: 20, H: 20}, true},
+		{"crosses a side", sdl.Rect{X: 120, Y: 60, W: 20, H: 50}, true},
+		{"crosses the arc", sdl.Rect{X: 150, Y: 180, W: 200, H: 5}, true},
+		{"behind", sdl.Rect{X: 20, Y: 20, W: 50, H: 50}, false},
+		{"too far", sdl.Rect{X: 200, Y: 200, W: 50, H: 50}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, testSector.OverlapsRect(&tt.rect))
+		})
+	}
+}
+
+func Test_Sector_Bounds(t *testing.T) {
+	b := testSector.Bounds()
+
+	assert.Equal(t, &sdl.Rect{X: 100, Y: 100, W: 101, H: 101}, b)
+}
+
+func Test_Angle(t *testing.T) {
+	assert.InDelta(t, -math.Pi/2, Angle(3*math.Pi/2), 1e-9)
+	assert.InDelta(t, math.Pi, Angle(-math.Pi), 1e-9)
+	assert.InDelta(t, 0, Angle(4*math.Pi), 1e-9)
+}
@@ -0,0 +1,28 @@
+package status
+
+const (
+	// Success means a node has done its job.
+	Success Type = iota
+	// Failure means a node could not do its job.
+	Failure
+	// Running means a node is still doing its job.
+	Running
+)
+
+var (
+	typeNames = map[Type]string{
+		Success: "Success",
+		Failure: "Failure",
+		Running: "Running",
+	}
+)
+
+// Type is a result of a tick of a behaviour tree node.
+type Type byte
+
+func (t Type) String() string {
+	if t > Running {
+		return "Unknown"
+	}
+	return typeNames[t]
+}
@@ -0,0 +1,31 @@
+package terrain
+
+const (
+	// Ground is a default terrain.
+	Ground Type = iota
+	// Ice is slippery.
+	Ice
+	// Mud is sticky and slow.
+	Mud
+	// Sand is slow.
+	Sand
+)
+
+var (
+	typeNames = map[Type]string{
+		Ground: "ground",
+		Ice:    "ice",
+		Mud:    "mud",
+		Sand:   "sand",
+	}
+)
+
+// Type is a type of terrain.
+type Type byte
+
+func (t Type) String() string {
+	if t > Sand {
+		return "unknown"
+	}
+	return typeNames[t]
+}
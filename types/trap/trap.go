@@ -0,0 +1,43 @@
+package trap
+
+import "fmt"
+
+const (
+	// Static pit stays in place.
+	Static Type = iota
+	// Moving pit slides back and forth along its path.
+	Moving
+	// Timed pit opens and closes from time to time.
+	Timed
+	// Crumbling floor tile turns into a pit after being stepped on.
+	Crumbling
+)
+
+var (
+	typeNames = map[Type]string{
+		Static:    "static",
+		Moving:    "moving",
+		Timed:     "timed",
+		Crumbling: "crumbling",
+	}
+)
+
+// Type is a type of a pit.
+type Type byte
+
+// Parse returns Type of a pit with the given "name", e.g. "moving".
+func Parse(name string) (Type, error) {
+	for t, n := range typeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown trap %s", name)
+}
+
+func (t Type) String() string {
+	if t > Crumbling {
+		return "unknown"
+	}
+	return typeNames[t]
+}
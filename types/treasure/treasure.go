@@ -0,0 +1,46 @@
+package treasure
+
+import "fmt"
+
+const (
+	// Static trove stays in place.
+	Static Type = iota
+	// Shy trove moves away from Hero, when approached.
+	Shy
+	// Fleeting trove appears only for a time window.
+	Fleeting
+	// Heavy trove is collected by standing still on it for a while.
+	Heavy
+	// Guarded trove has an enemy nearby.
+	Guarded
+)
+
+var (
+	typeNames = map[Type]string{
+		Static:   "static",
+		Shy:      "shy",
+		Fleeting: "fleeting",
+		Heavy:    "heavy",
+		Guarded:  "guarded",
+	}
+)
+
+// Type is a type of a trove.
+type Type byte
+
+// Parse returns Type of a trove with the given "name", e.g. "shy".
+func Parse(name string) (Type, error) {
+	for t, n := range typeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown trove %s", name)
+}
+
+func (t Type) String() string {
+	if t > Guarded {
+		return "unknown"
+	}
+	return typeNames[t]
+}
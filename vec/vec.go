@@ -0,0 +1,89 @@
+// Package vec implements 2D vector math for positions and velocities of objects.
+package vec
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Vec is a 2D vector, y axis points down on the screen.
+type Vec struct {
+	X, Y float64
+}
+
+// Of returns Vec of the given point "p".
+func Of(p sdl.Point) Vec {
+	return Vec{X: float64(p.X), Y: float64(p.Y)}
+}
+
+// FromAngle returns a unit Vec of the given angle "a" in radians, clockwise from East.
+func FromAngle(a float64) Vec {
+	return Vec{X: math.Cos(a), Y: math.Sin(a)}
+}
+
+// Add returns sum of the vectors.
+func (v Vec) Add(u Vec) Vec {
+	return Vec{X: v.X + u.X, Y: v.Y + u.Y}
+}
+
+// Sub returns difference of the vectors.
+func (v Vec) Sub(u Vec) Vec {
+	return Vec{X: v.X - u.X, Y: v.Y - u.Y}
+}
+
+// Scale returns Vec multiplied by "k".
+func (v Vec) Scale(k float64) Vec {
+	return Vec{X: v.X * k, Y: v.Y * k}
+}
+
+// Dot returns dot product of the vectors.
+func (v Vec) Dot(u Vec) float64 {
+	return v.X*u.X + v.Y*u.Y
+}
+
+// Len returns length of Vec.
+func (v Vec) Len() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Angle returns angle of Vec in radians, clockwise from East.
+func (v Vec) Angle() float64 {
+	return math.Atan2(v.Y, v.X)
+}
+
+// IsZero tells whether Vec has zero length.
+func (v Vec) IsZero() bool {
+	return v.X == 0 && v.Y == 0
+}
+
+// Norm returns a unit Vec of the same direction, zero Vec stays zero.
+func (v Vec) Norm() Vec {
+	l := v.Len()
+	if l == 0 {
+		return v
+	}
+	return v.Scale(1 / l)
+}
+
+// Limit returns Vec of the same direction, which is not longer than "max".
+func (v Vec) Limit(max float64) Vec {
+	if l := v.Len(); l > max {
+		return v.Scale(max / l)
+	}
+	return v
+}
+
+// Shrink returns Vec of the same direction, which is shorter by "d", but not shorter than zero, e.g. to apply friction.
+func (v Vec) Shrink(d float64) Vec {
+	l := v.Len()
+	if l <= d {
+		return Vec{}
+	}
+	return v.Scale((l - d) / l)
+}
+
+// Point returns Vec rounded to the closest point.
+func (v Vec) Point() sdl.Point {
+	return sdl.Point{X: int32(math.Round(v.X)), Y: int32(math.Round(v.Y))}
+}
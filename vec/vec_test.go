@@ -0,0 +1,42 @@
+package vec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func Test_Vec_Limit(t *testing.T) {
+	v := Vec{X: 4, Y: 4}.Limit(4)
+
+	assert.InDelta(t, 4, v.Len(), 1e-9)
+	assert.InDelta(t, math.Pi/4, v.Angle(), 1e-9)
+	assert.Equal(t, Vec{X: 1, Y: 2}, Vec{X: 1, Y: 2}.Limit(4))
+}
+
+func Test_Vec_Shrink(t *testing.T) {
+	assert.Equal(t, Vec{X: 0, Y: 2.5}, Vec{X: 0, Y: 3}.Shrink(0.5))
+	assert.Equal(t, Vec{}, Vec{X: 0.3, Y: 0.4}.Shrink(0.5))
+}
+
+func Test_Vec_Norm(t *testing.T) {
+	v := Vec{X: 3, Y: 4}.Norm()
+
+	assert.InDelta(t, 0.6, v.X, 1e-9)
+	assert.InDelta(t, 0.8, v.Y, 1e-9)
+	assert.Equal(t, Vec{}, Vec{}.Norm())
+}
+
+func Test_Vec_Point(t *testing.T) {
+	assert.Equal(t, sdl.Point{X: 2, Y: -3}, Vec{X: 1.5, Y: -2.6}.Point())
+	assert.Equal(t, Vec{X: 2, Y: -3}, Of(sdl.Point{X: 2, Y: -3}))
+}
+
+func Test_FromAngle(t *testing.T) {
+	v := FromAngle(math.Pi / 2)
+
+	assert.InDelta(t, 0, v.X, 1e-9)
+	assert.InDelta(t, 1, v.Y, 1e-9)
+}
@@ -0,0 +1,36 @@
+package wall
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Size is a thickness of walls.
+const Size = 10
+
+// Wall blocks the way of everyone.
+type Wall struct {
+	ID string
+
+	X, Y int32
+	W, H int32
+}
+
+// NewWall creates new instance of Wall.
+func NewWall(id string, x, y, width, height int32) *Wall {
+	return &Wall{
+		ID: id,
+		X:  x,
+		Y:  y,
+		W:  width,
+		H:  height,
+	}
+}
+
+// Paint ...
+func (w *Wall) Paint(r *sdl.Renderer) error {
+	r.SetDrawColor(110, 110, 110, 255)
+	r.FillRect(&sdl.Rect{X: w.X, Y: w.Y, W: w.W, H: w.H})
+	r.SetDrawColor(0, 0, 0, 255)
+
+	return nil
+}
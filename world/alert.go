@@ -0,0 +1,62 @@
+package world
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/alert"
+)
+
+const (
+	// alarmTicks is a number of ticks alarm lasts after Hero was last seen
+	alarmTicks = 300
+	// cautionTicks is a number of ticks caution lasts after alarm
+	cautionTicks = 500
+)
+
+// Alert is an alert level of the World along with the last known position of Hero.
+type Alert struct {
+	Level alert.Type
+	// LastKnown position of Hero
+	LastKnown sdl.Point
+	// Left is a number of ticks left till the Level decays
+	Left int64
+}
+
+// RaiseAlert raises alarm in the whole level, Hero was seen at the point "p".
+func (w *World) RaiseAlert(p sdl.Point) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.alert = Alert{Level: alert.Alarm, LastKnown: p, Left: alarmTicks}
+}
+
+// Spot raises alarm, as an enemy has seen Hero at the point "p".
+func (w *World) Spot(p sdl.Point) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.alert = Alert{Level: alert.Alarm, LastKnown: p, Left: alarmTicks}
+	w.spotted = true
+}
+
+// Alert returns the current Alert of the World.
+func (w *World) Alert() Alert {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.alert
+}
+
+// decayAlert lowers alert level, once its time is up.
+func (w *World) decayAlert() {
+	if w.alert.Level == alert.Calm {
+		return
+	}
+	if w.alert.Left--; w.alert.Left > 0 {
+		return
+	}
+	switch w.alert.Level {
+	case alert.Alarm:
+		w.alert.Level = alert.Caution
+		w.alert.Left = cautionTicks
+	default:
+		w.alert = Alert{}
+	}
+}
@@ -0,0 +1,43 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/alert"
+)
+
+func Test_Alert_decays(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	assert.Equal(t, alert.Calm, w.Alert().Level)
+
+	w.RaiseAlert(sdl.Point{X: 100, Y: 200})
+	assert.Equal(t, Alert{Level: alert.Alarm, LastKnown: sdl.Point{X: 100, Y: 200}, Left: alarmTicks}, w.Alert())
+
+	for i := 0; i < alarmTicks; i++ {
+		w.Update()
+	}
+	assert.Equal(t, alert.Caution, w.Alert().Level)
+
+	for i := 0; i < cautionTicks; i++ {
+		w.Update()
+	}
+	assert.Equal(t, alert.Calm, w.Alert().Level)
+}
+
+func Test_RaiseAlert_renews_alarm(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	w.RaiseAlert(sdl.Point{X: 100, Y: 200})
+	for i := 0; i < alarmTicks-1; i++ {
+		w.Update()
+	}
+	w.RaiseAlert(sdl.Point{X: 300, Y: 200})
+	w.Update()
+
+	assert.Equal(t, alert.Alarm, w.Alert().Level)
+	assert.Equal(t, sdl.Point{X: 300, Y: 200}, w.Alert().LastKnown)
+}
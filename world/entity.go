@@ -0,0 +1,169 @@
+package world
+
+import (
+	"sort"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/kind"
+)
+
+// Entity is a record of an object in the World.
+type Entity struct {
+	ID       string    `json:"id"`
+	Kind     kind.Type `json:"kind"`
+	Bounds   sdl.Rect  `json:"bounds"`
+	Altitude float64   `json:"altitude"`
+}
+
+// Center returns center point of the Entity.
+func (e Entity) Center() sdl.Point {
+	return sdl.Point{X: e.Bounds.X + e.Bounds.W/2, Y: e.Bounds.Y + e.Bounds.H/2}
+}
+
+// Snapshot is a serialisable state of the World.
+type Snapshot struct {
+	Level    int      `json:"level"`
+	Score    int      `json:"score"`
+	Entities []Entity `json:"entities"`
+}
+
+// set registers or replaces the Entity, must be called under the lock.
+func (w *World) set(e *Entity) {
+	w.entities[e.ID] = e
+	w.grid.Set(e.ID, e.Bounds)
+}
+
+// Move updates position and altitude of the registered object with the given "objID",
+// objects are registered by RandomizePos, unknown objects are ignored.
+func (w *World) Move(objID string, pos *sdl.Rect, altitude float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	e, ok := w.entities[objID]
+	if !ok {
+		return
+	}
+	e.Bounds = *pos
+	e.Altitude = altitude
+	w.grid.Set(objID, *pos)
+}
+
+// Remove removes object with the given "objID" from the world.
+func (w *World) Remove(objID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.entities, objID)
+	w.grid.Remove(objID)
+}
+
+// Clear removes all objects from the world, e.g. before the level restart.
+func (w *World) Clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.entities = make(map[string]*Entity)
+	w.grid.Clear()
+	w.noises = nil
+	w.impacts = nil
+	w.alert = Alert{}
+	w.zones = nil
+	w.combo, w.comboAt = 0, 0
+	w.tick = 0
+}
+
+// Entity returns the Entity with the given "objID".
+func (w *World) Entity(objID string) (Entity, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	e, ok := w.entities[objID]
+	if !ok {
+		return Entity{}, false
+	}
+	return *e, true
+}
+
+// EntitiesIn returns all entities, which overlap with the given "area", ordered by ID.
+func (w *World) EntitiesIn(area *sdl.Rect) []Entity {
+	// grid query updates its internal state, hence the write lock
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var found []Entity
+	w.grid.Each(*area, func(id string, _ sdl.Rect) bool {
+		if e, ok := w.entities[id]; ok {
+			found = append(found, *e)
+		}
+		return true
+	})
+	sortByID(found)
+
+	return found
+}
+
+// OfKind returns all entities of the given kind "k", ordered by ID.
+func (w *World) OfKind(k kind.Type) []Entity {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var found []Entity
+	for _, e := range w.entities {
+		if e.Kind == k {
+			found = append(found, *e)
+		}
+	}
+	sortByID(found)
+
+	return found
+}
+
+// Nearest returns the closest to the point "from" entity of the given kind "k".
+func (w *World) Nearest(k kind.Type, from sdl.Point) (Entity, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var nearest *Entity
+	var best int64
+	for _, e := range w.entities {
+		if e.Kind != k {
+			continue
+		}
+		c := e.Center()
+		dx, dy := int64(c.X-from.X), int64(c.Y-from.Y)
+		dist := dx*dx + dy*dy
+		// ties are resolved by ID to keep result stable
+		if nearest == nil || dist < best || (dist == best && e.ID < nearest.ID) {
+			nearest = e
+			best = dist
+		}
+	}
+
+	if nearest == nil {
+		return Entity{}, false
+	}
+	return *nearest, true
+}
+
+// Snapshot returns serialisable state of the World.
+func (w *World) Snapshot() Snapshot {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	entities := make([]Entity, 0, len(w.entities))
+	for _, e := range w.entities {
+		entities = append(entities, *e)
+	}
+	sortByID(entities)
+
+	return Snapshot{
+		Level:    w.level,
+		Score:    w.score,
+		Entities: entities,
+	}
+}
+
+func sortByID(entities []Entity) {
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].ID < entities[j].ID
+	})
+}
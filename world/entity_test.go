@@ -0,0 +1,103 @@
+package world
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/kind"
+)
+
+func newTestWorld() *World {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.set(&Entity{ID: "hero", Kind: kind.Hero, Bounds: sdl.Rect{X: 100, Y: 100, W: 50, H: 50}})
+	w.set(&Entity{ID: "enemy-0", Kind: kind.Enemy, Bounds: sdl.Rect{X: 300, Y: 100, W: 50, H: 50}})
+	w.set(&Entity{ID: "enemy-1", Kind: kind.Enemy, Bounds: sdl.Rect{X: 800, Y: 800, W: 50, H: 50}})
+	w.set(&Entity{ID: "pit-0", Kind: kind.Pit, Bounds: sdl.Rect{X: 150, Y: 400, W: 100, H: 100}})
+	return w
+}
+
+func Test_Place_registers(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	pos, err := w.Place("trove-0", kind.Trove, 50, 50)
+	assert.NoError(t, err)
+
+	e, ok := w.Entity("trove-0")
+	assert.True(t, ok)
+	assert.Equal(t, kind.Trove, e.Kind)
+	assert.Equal(t, *pos, e.Bounds)
+}
+
+func Test_Move(t *testing.T) {
+	w := newTestWorld()
+
+	w.Move("hero", &sdl.Rect{X: 600, Y: 600, W: 50, H: 50}, 10)
+	w.Move("unknown", &sdl.Rect{X: 600, Y: 600, W: 50, H: 50}, 0)
+
+	e, _ := w.Entity("hero")
+	assert.Equal(t, sdl.Rect{X: 600, Y: 600, W: 50, H: 50}, e.Bounds)
+	assert.Equal(t, float64(10), e.Altitude)
+	_, ok := w.Entity("unknown")
+	assert.False(t, ok)
+	assert.Empty(t, w.EntitiesIn(&sdl.Rect{X: 100, Y: 100, W: 50, H: 50}))
+}
+
+func Test_EntitiesIn(t *testing.T) {
+	w := newTestWorld()
+
+	found := w.EntitiesIn(&sdl.Rect{X: 0, Y: 0, W: 400, H: 200})
+
+	assert.Len(t, found, 2)
+	assert.Equal(t, "enemy-0", found[0].ID)
+	assert.Equal(t, "hero", found[1].ID)
+}
+
+func Test_OfKind(t *testing.T) {
+	w := newTestWorld()
+
+	found := w.OfKind(kind.Enemy)
+
+	assert.Len(t, found, 2)
+	assert.Equal(t, "enemy-0", found[0].ID)
+	assert.Equal(t, "enemy-1", found[1].ID)
+}
+
+func Test_Nearest(t *testing.T) {
+	w := newTestWorld()
+
+	e, ok := w.Nearest(kind.Enemy, sdl.Point{X: 700, Y: 700})
+	assert.True(t, ok)
+	assert.Equal(t, "enemy-1", e.ID)
+
+	_, ok = w.Nearest(kind.Trove, sdl.Point{X: 700, Y: 700})
+	assert.False(t, ok)
+}
+
+func Test_Remove(t *testing.T) {
+	w := newTestWorld()
+
+	w.Remove("pit-0")
+
+	_, ok := w.Entity("pit-0")
+	assert.False(t, ok)
+	assert.Empty(t, w.EntitiesIn(&sdl.Rect{X: 150, Y: 400, W: 100, H: 100}))
+}
+
+func Test_Snapshot(t *testing.T) {
+	w := newTestWorld()
+	w.Collect(1)
+
+	data, err := json.Marshal(w.Snapshot())
+	assert.NoError(t, err)
+
+	var s Snapshot
+	assert.NoError(t, json.Unmarshal(data, &s))
+	assert.Equal(t, 1, s.Score)
+	assert.Len(t, s.Entities, 4)
+	assert.Equal(t, "enemy-0", s.Entities[0].ID)
+	assert.Equal(t, kind.Enemy, s.Entities[0].Kind)
+}
@@ -0,0 +1,27 @@
+package world
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// Impact is a shockwave, which stuns everyone within the Radius from its Source.
+type Impact Noise
+
+// MakeImpact makes an Impact, which can be felt in the World till the end of the current tick.
+func (w *World) MakeImpact(source sdl.Point, radius int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.impacts = append(w.impacts, Impact{Source: source, Radius: radius})
+}
+
+// ImpactsAt returns impacts of the current tick, which can be felt at the point "p".
+func (w *World) ImpactsAt(p sdl.Point) []Impact {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var felt []Impact
+	for _, i := range w.impacts {
+		if Noise(i).Reaches(p) {
+			felt = append(felt, i)
+		}
+	}
+	return felt
+}
@@ -0,0 +1,36 @@
+package world
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// Noise is a sound, which can be heard within the Radius from its Source.
+type Noise struct {
+	Source sdl.Point
+	Radius int32
+}
+
+// Reaches tells whether the Noise can be heard at the point "p".
+func (n Noise) Reaches(p sdl.Point) bool {
+	dx, dy := int64(p.X-n.Source.X), int64(p.Y-n.Source.Y)
+	return dx*dx+dy*dy <= int64(n.Radius)*int64(n.Radius)
+}
+
+// MakeNoise makes a Noise, which can be heard in the World till the end of the current tick.
+func (w *World) MakeNoise(source sdl.Point, radius int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.noises = append(w.noises, Noise{Source: source, Radius: radius})
+}
+
+// NoisesAt returns noises of the current tick, which can be heard at the point "p".
+func (w *World) NoisesAt(p sdl.Point) []Noise {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var heard []Noise
+	for _, n := range w.noises {
+		if n.Reaches(p) {
+			heard = append(heard, n)
+		}
+	}
+	return heard
+}
@@ -0,0 +1,27 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+)
+
+func Test_NoisesAt(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.MakeNoise(sdl.Point{X: 100, Y: 100}, 100)
+
+	assert.Len(t, w.NoisesAt(sdl.Point{X: 160, Y: 180}), 1)
+	assert.Empty(t, w.NoisesAt(sdl.Point{X: 180, Y: 180}))
+}
+
+func Test_Update_noises_fade(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.MakeNoise(sdl.Point{X: 100, Y: 100}, 100)
+
+	w.Update()
+
+	assert.Empty(t, w.NoisesAt(sdl.Point{X: 100, Y: 100}))
+}
@@ -0,0 +1,129 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/types/kind"
+)
+
+const (
+	// posMargin is a default gap in between any objects
+	posMargin = 100
+
+	// distance in between candidate positions
+	placeStep = 10
+)
+
+// ErrNoSpace is returned when there is no position left for an object.
+var ErrNoSpace = errors.New("no space left")
+
+// Clearance is a gap, which must be kept in between a placed object and objects of the given Kind,
+// zero Kind (kind.Unknown) matches objects of any kind.
+type Clearance struct {
+	Kind   kind.Type
+	Margin int32
+}
+
+// Place finds a random free position for the given "objID", "objW" and "objH"
+// and registers object of the given kind "k" in the world.
+// Besides the default gap to any object, additional clearance "rules" can be given,
+// e.g. to keep enemies far from the Hero. Candidate positions are sampled from a grid
+// in random order, so that Place either finds a position or returns ErrNoSpace.
+func (w *World) Place(objID string, k kind.Type, objW, objH int32, rules ...Clearance) (*sdl.Rect, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if objW <= 0 || objH <= 0 || objW > w.W || objH > w.H {
+		return nil, fmt.Errorf("could not place %s of size %dx%d: %w", objID, objW, objH, ErrNoSpace)
+	}
+
+	rules = append([]Clearance{{Margin: posMargin}}, rules...)
+
+	// decrement by size in order to fit whole object at the rightmost side and at the bottom
+	maxX, maxY := w.W-objW, w.H-objH
+	cols, rows := maxX/placeStep+1, maxY/placeStep+1
+
+	// shift the grid a bit, so that objects don't line up
+	jitterX, jitterY := w.Rand.Int31n(placeStep), w.Rand.Int31n(placeStep)
+
+	for _, i := range w.Rand.Perm(int(cols * rows)) {
+		pos := sdl.Rect{
+			X: min(int32(i)%cols*placeStep+jitterX, maxX),
+			Y: min(int32(i)/cols*placeStep+jitterY, maxY),
+			W: objW,
+			H: objH,
+		}
+		if w.isClear(objID, pos, rules) {
+			w.set(&Entity{ID: objID, Kind: k, Bounds: pos})
+			return &pos, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not place %s: %w", objID, ErrNoSpace)
+}
+
+// PlaceAt registers object of the given kind "k" at the given position "pos", if it keeps clearance "rules",
+// and tells whether it has done so. Unlike Place, there is no default gap, so that objects can stand
+// side by side, e.g. a guard next to the trove it guards.
+func (w *World) PlaceAt(objID string, k kind.Type, pos sdl.Rect, rules ...Clearance) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if pos.X < 0 || pos.Y < 0 || pos.X+pos.W > w.W || pos.Y+pos.H > w.H {
+		return false
+	}
+	if !w.isClear(objID, pos, rules) {
+		return false
+	}
+	w.set(&Entity{ID: objID, Kind: k, Bounds: pos})
+	return true
+}
+
+// Register registers object of the given kind "k" at the given position "pos",
+// e.g. to keep an object, which could not be placed elsewhere.
+func (w *World) Register(objID string, k kind.Type, pos *sdl.Rect) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.set(&Entity{ID: objID, Kind: k, Bounds: *pos})
+}
+
+// isClear checks that none of the "rules" is violated at the given position "pos",
+// must be called under the lock.
+func (w *World) isClear(objID string, pos sdl.Rect, rules []Clearance) bool {
+	for _, rule := range rules {
+		// clearenceZone has an extra margin on each side to provide a gap in between objects
+		clearenceZone := sdl.Rect{
+			X: pos.X - rule.Margin,
+			Y: pos.Y - rule.Margin,
+			W: pos.W + 2*rule.Margin,
+			H: pos.H + 2*rule.Margin,
+		}
+
+		clear := true
+		w.grid.Each(clearenceZone, func(id string, _ sdl.Rect) bool {
+			// skip itself hence it will be replaced anyway at the end
+			if id == objID {
+				return true
+			}
+			if e, ok := w.entities[id]; ok && (rule.Kind == kind.Unknown || e.Kind == rule.Kind) {
+				clear = false
+				return false
+			}
+			return true
+		})
+		if !clear {
+			return false
+		}
+	}
+	return true
+}
+
+func min(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
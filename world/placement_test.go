@@ -0,0 +1,82 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/spatial"
+	"github.com/smeshkov/trovehero/types/kind"
+)
+
+func Test_Place_keeps_margin(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	for i := 0; i < 10; i++ {
+		_, err := w.Place(fmt.Sprintf("trove-%d", i), kind.Trove, 50, 50)
+		assert.NoError(t, err)
+	}
+
+	troves := w.OfKind(kind.Trove)
+	for _, a := range troves {
+		zone := sdl.Rect{X: a.Bounds.X - posMargin, Y: a.Bounds.Y - posMargin, W: a.Bounds.W + 2*posMargin, H: a.Bounds.H + 2*posMargin}
+		for _, b := range troves {
+			if a.ID != b.ID {
+				assert.False(t, spatial.Overlaps(zone, b.Bounds), "%s is too close to %s", a.ID, b.ID)
+			}
+		}
+	}
+}
+
+func Test_Place_clearance(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.Register("hero", kind.Hero, &sdl.Rect{X: 475, Y: 475, W: 50, H: 50})
+
+	for i := 0; i < 5; i++ {
+		pos, err := w.Place(fmt.Sprintf("enemy-%d", i), kind.Enemy, 50, 50, Clearance{Kind: kind.Hero, Margin: 300})
+		assert.NoError(t, err)
+		zone := sdl.Rect{X: pos.X - 300, Y: pos.Y - 300, W: pos.W + 600, H: pos.H + 600}
+		assert.False(t, spatial.Overlaps(zone, sdl.Rect{X: 475, Y: 475, W: 50, H: 50}))
+	}
+}
+
+func Test_Place_no_space(t *testing.T) {
+	w := NewWorld(300, 300, nil, 0, audio.Silent{})
+
+	var err error
+	var placed int
+	for i := 0; i < 100 && err == nil; i++ {
+		_, err = w.Place(fmt.Sprintf("pit-%d", i), kind.Pit, 50, 50)
+		if err == nil {
+			placed++
+		}
+	}
+
+	assert.True(t, errors.Is(err, ErrNoSpace))
+	assert.True(t, placed > 0)
+	assert.Len(t, w.OfKind(kind.Pit), placed)
+}
+
+func Test_Place_too_big(t *testing.T) {
+	w := NewWorld(100, 100, nil, 0, audio.Silent{})
+
+	_, err := w.Place("pit-0", kind.Pit, 150, 50)
+
+	assert.True(t, errors.Is(err, ErrNoSpace))
+}
+
+func Test_PlaceAt(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.Register("trove", kind.Trove, &sdl.Rect{X: 100, Y: 100, W: 50, H: 50})
+
+	assert.True(t, w.PlaceAt("guard", kind.Enemy, sdl.Rect{X: 160, Y: 100, W: 50, H: 50}, Clearance{Margin: 5}))
+	assert.Equal(t, kind.Enemy, w.OfKind(kind.Enemy)[0].Kind)
+
+	assert.False(t, w.PlaceAt("enemy", kind.Enemy, sdl.Rect{X: 120, Y: 120, W: 50, H: 50}, Clearance{Margin: 5}), "overlaps the trove")
+	assert.False(t, w.PlaceAt("enemy", kind.Enemy, sdl.Rect{X: 980, Y: 100, W: 50, H: 50}), "out of the world")
+	assert.Len(t, w.OfKind(kind.Enemy), 1)
+}
@@ -0,0 +1,107 @@
+package world
+
+import (
+	"time"
+
+	"github.com/smeshkov/trovehero/types/score"
+)
+
+const (
+	// comboTicks is a number of ticks, within which the next trove continues the combo.
+	comboTicks = 300
+	// maxCombo is the largest multiplier of the combo.
+	maxCombo = 5
+	// timeBonus is scored for every second under the par time.
+	timeBonus = 2
+	// undetectedBonus is scored for completing a level, while no enemy has seen Hero.
+	undetectedBonus = 50
+	// DeathPenalty is taken for every life lost.
+	DeathPenalty = 20
+)
+
+// ScoreEvent is a change of the player's score.
+type ScoreEvent struct {
+	Reason score.Type `json:"reason"`
+	Points int        `json:"points"`
+	// Combo multiplier, the points were scored with
+	Combo int `json:"combo,omitempty"`
+}
+
+// Collect scores a trove worth "points", troves collected one after another within a short time
+// make a combo, which multiplies their points. It returns points actually scored.
+func (w *World) Collect(points int) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.combo > 0 && w.tick-w.comboAt <= comboTicks {
+		w.combo++
+	} else {
+		w.combo = 1
+	}
+	if w.combo > maxCombo {
+		w.combo = maxCombo
+	}
+	w.comboAt = w.tick
+
+	points *= w.combo
+	w.addScore(ScoreEvent{Reason: score.Trove, Points: points, Combo: w.combo})
+	return points
+}
+
+// AddScore adds "n" points to player's score for the reason "r", negative "n" is a penalty.
+func (w *World) AddScore(r score.Type, n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.addScore(ScoreEvent{Reason: r, Points: n})
+}
+
+// addScore logs the event and changes the score, which never goes below zero, must be called under the lock.
+func (w *World) addScore(e ScoreEvent) {
+	if w.score+e.Points < 0 {
+		e.Points = -w.score
+	}
+	w.score += e.Points
+	w.scoreLog = append(w.scoreLog, e)
+}
+
+// Finish scores bonuses of the completed level, which took "spent" time: a bonus for every second
+// under the "par" time and a bonus, if no enemy has seen Hero.
+func (w *World) Finish(spent, par time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if left := int((par - spent) / time.Second); left > 0 {
+		w.addScore(ScoreEvent{Reason: score.Time, Points: left * timeBonus})
+	}
+	if !w.spotted {
+		w.addScore(ScoreEvent{Reason: score.Undetected, Points: undetectedBonus})
+	}
+}
+
+// Combo returns the current combo multiplier, it is zero, once the combo is over.
+func (w *World) Combo() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.tick-w.comboAt > comboTicks {
+		return 0
+	}
+	return w.combo
+}
+
+// ScoreLog returns changes of the score since the level started.
+func (w *World) ScoreLog() []ScoreEvent {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]ScoreEvent(nil), w.scoreLog...)
+}
+
+// ScoreBreakdown sums up the score log by reasons.
+func (w *World) ScoreBreakdown() map[score.Type]int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	sums := make(map[score.Type]int)
+	for _, e := range w.scoreLog {
+		sums[e.Reason] += e.Points
+	}
+	return sums
+}
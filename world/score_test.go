@@ -0,0 +1,92 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/score"
+)
+
+func Test_Collect_combo(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	assert.Equal(t, 2, w.Collect(2))
+	w.Update()
+	assert.Equal(t, 4, w.Collect(2), "second trove in a row")
+	assert.Equal(t, 2, w.Combo())
+
+	for i := 0; i <= comboTicks; i++ {
+		w.Update()
+	}
+	assert.Equal(t, 0, w.Combo())
+	assert.Equal(t, 2, w.Collect(2), "combo is over")
+
+	assert.Equal(t, 8, w.GetScore())
+	assert.Equal(t, []ScoreEvent{
+		{Reason: score.Trove, Points: 2, Combo: 1},
+		{Reason: score.Trove, Points: 4, Combo: 2},
+		{Reason: score.Trove, Points: 2, Combo: 1},
+	}, w.ScoreLog())
+}
+
+func Test_Collect_max_combo(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	for i := 0; i < maxCombo+2; i++ {
+		w.Collect(1)
+	}
+
+	assert.Equal(t, maxCombo, w.Combo())
+}
+
+func Test_AddScore_penalty(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.AddScore(score.Gem, 5)
+
+	w.AddScore(score.Death, -DeathPenalty)
+
+	assert.Equal(t, 0, w.GetScore(), "score doesn't go below zero")
+	assert.Equal(t, map[score.Type]int{score.Gem: 5, score.Death: -5}, w.ScoreBreakdown())
+}
+
+func Test_Finish(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+
+	w.Finish(50*time.Second, time.Minute)
+
+	assert.Equal(t, map[score.Type]int{score.Time: 10 * timeBonus, score.Undetected: undetectedBonus}, w.ScoreBreakdown())
+}
+
+func Test_Finish_spotted(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.Spot(sdl.Point{X: 100, Y: 100})
+	w.Clear()
+
+	w.Finish(2*time.Minute, time.Minute)
+
+	assert.Empty(t, w.ScoreLog(), "no bonus after being seen and too slow")
+
+	w.IncLevel()
+	w.Finish(2*time.Minute, time.Minute)
+	assert.Equal(t, map[score.Type]int{score.Undetected: undetectedBonus}, w.ScoreBreakdown(), "next level starts anew")
+}
+
+func Test_NewGame(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 3, audio.Silent{})
+	w.Collect(5)
+	w.Spot(sdl.Point{X: 100, Y: 100})
+	w.IncLevel()
+
+	w.NewGame(1)
+
+	assert.Equal(t, 1, w.GetLevel())
+	assert.Equal(t, 0, w.GetScore())
+	assert.Equal(t, 0, w.Combo())
+	assert.Empty(t, w.ScoreLog())
+	w.Finish(2*time.Minute, time.Minute)
+	assert.Equal(t, map[score.Type]int{score.Undetected: undetectedBonus}, w.ScoreBreakdown())
+}
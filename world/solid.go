@@ -0,0 +1,20 @@
+package world
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// Blocked tells whether any solid object, e.g. a wall, overlaps the area "r".
+func (w *World) Blocked(r *sdl.Rect) bool {
+	// grid query updates its internal state, hence the write lock
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	blocked := false
+	w.grid.Each(*r, func(id string, _ sdl.Rect) bool {
+		if e, ok := w.entities[id]; ok && e.Kind.IsSolid() {
+			blocked = true
+			return false
+		}
+		return true
+	})
+	return blocked
+}
@@ -0,0 +1,21 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/kind"
+)
+
+func Test_Blocked(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.Register("wall-0", kind.Wall, &sdl.Rect{X: 100, Y: 100, W: 10, H: 200})
+	w.Register("pit-0", kind.Pit, &sdl.Rect{X: 300, Y: 100, W: 100, H: 100})
+
+	assert.True(t, w.Blocked(&sdl.Rect{X: 60, Y: 150, W: 50, H: 50}))
+	assert.False(t, w.Blocked(&sdl.Rect{X: 50, Y: 150, W: 50, H: 50}), "adjacent objects don't overlap")
+	assert.False(t, w.Blocked(&sdl.Rect{X: 320, Y: 120, W: 50, H: 50}), "pits are not solid")
+}
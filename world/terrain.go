@@ -0,0 +1,47 @@
+package world
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/physics"
+	"github.com/smeshkov/trovehero/types/terrain"
+)
+
+// Zone is an area of the World covered with a terrain other than ground.
+type Zone struct {
+	ID      string       `json:"id"`
+	Bounds  sdl.Rect     `json:"bounds"`
+	Terrain terrain.Type `json:"terrain"`
+}
+
+// AddZone adds the Zone "z" to the World, zones added later cover the earlier ones.
+func (w *World) AddZone(z Zone) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.zones = append(w.zones, z)
+}
+
+// Zones returns all zones of the World in order they were added.
+func (w *World) Zones() []Zone {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]Zone(nil), w.zones...)
+}
+
+// TerrainAt returns terrain at the point "p".
+func (w *World) TerrainAt(p sdl.Point) terrain.Type {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for i := len(w.zones) - 1; i >= 0; i-- {
+		if p.InRect(&w.zones[i].Bounds) {
+			return w.zones[i].Terrain
+		}
+	}
+	return terrain.Ground
+}
+
+// SurfaceAt returns Surface at the point "p".
+func (w *World) SurfaceAt(p sdl.Point) physics.Surface {
+	return w.Physics.Surface(w.TerrainAt(p))
+}
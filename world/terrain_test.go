@@ -0,0 +1,25 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/types/terrain"
+)
+
+func Test_TerrainAt(t *testing.T) {
+	w := NewWorld(1000, 1000, nil, 0, audio.Silent{})
+	w.AddZone(Zone{ID: "zone-0", Bounds: sdl.Rect{X: 100, Y: 100, W: 200, H: 200}, Terrain: terrain.Mud})
+	w.AddZone(Zone{ID: "zone-1", Bounds: sdl.Rect{X: 200, Y: 200, W: 200, H: 200}, Terrain: terrain.Ice})
+
+	assert.Equal(t, terrain.Ground, w.TerrainAt(sdl.Point{X: 50, Y: 50}))
+	assert.Equal(t, terrain.Mud, w.TerrainAt(sdl.Point{X: 150, Y: 150}))
+	assert.Equal(t, terrain.Ice, w.TerrainAt(sdl.Point{X: 250, Y: 250}))
+
+	w.Clear()
+
+	assert.Equal(t, terrain.Ground, w.TerrainAt(sdl.Point{X: 150, Y: 150}))
+}
@@ -6,9 +6,16 @@ import (
 	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
+
+	"github.com/smeshkov/trovehero/audio"
+	"github.com/smeshkov/trovehero/physics"
+	"github.com/smeshkov/trovehero/spatial"
 )
 
-const posMargin = 100
+const (
+	// size of the spatial index cell, comparable to the size of objects
+	gridCell = 100
+)
 
 var (
 	r = rand.New(rand.NewSource(time.Now().UTC().Unix()))
@@ -21,8 +28,29 @@ type World struct {
 	// randomizer
 	Rand *rand.Rand
 
-	// map of all objects' positions in the world
-	pos map[string]*sdl.Rect
+	// sound effects and music
+	Audio audio.Player
+
+	// Physics shared by all objects
+	Physics physics.Config
+
+	// registry of all objects in the world
+	entities map[string]*Entity
+
+	// spatial index of all objects' positions in the world
+	grid *spatial.Grid
+
+	// noises made during the current tick
+	noises []Noise
+
+	// impacts made during the current tick
+	impacts []Impact
+
+	// alert level of the world
+	alert Alert
+
+	// areas with terrain other than ground
+	zones []Zone
 
 	// size
 	H int32
@@ -32,93 +60,85 @@ type World struct {
 	vp *sdl.Rect
 
 	// holds player's score
-	score int8
+	score int
+	// changes of the score since the level started
+	scoreLog []ScoreEvent
+	// combo multiplier and the tick of the last trove collected
+	combo   int
+	comboAt int64
+	// spotted tells whether any enemy has seen Hero since the level started
+	spotted bool
+
+	// number of ticks since the level started
+	tick int64
 
 	// level
-	level int8
+	level int
 }
 
 // NewWorld ...
-func NewWorld(width, height int32, screen *sdl.Rect, level int8) *World {
+func NewWorld(width, height int32, screen *sdl.Rect, level int, player audio.Player) *World {
 	return &World{
-		Rand:  rand.New(rand.NewSource(time.Now().UTC().Unix())),
-		pos:   make(map[string]*sdl.Rect),
-		W:     width,
-		H:     height,
-		vp:    screen,
-		level: level,
-	}
-}
-
-// RandomizePos - randomizes position for the given "objID", "objW" and "objH".
-func (w *World) RandomizePos(objID string, objW, objH int32) *sdl.Rect {
-	var passed bool
-	var pos *sdl.Rect
-
-	for !passed {
-		x := w.Rand.Int31n(w.W - objW) // decrement by width in order to fit whole object at the rightmost side
-		y := w.Rand.Int31n(w.H - objH) // decrement by height in order to fit whole object at the bottom
-		pos = &sdl.Rect{X: x, Y: y, W: objW, H: objH}
-		passed = true
-
-		for key, p := range w.pos {
-			// skip itself hence it will be replaced anyway at the end
-			if key == objID {
-				continue
-			}
-
-			// clearenceZone has an extra margin to provide a gap in between objects
-			clearenceZone := &sdl.Rect{
-				X: p.X - posMargin,
-				Y: p.Y - posMargin,
-				W: p.W + posMargin,
-				H: p.H + posMargin,
-			}
-
-			if clearenceZone.HasIntersection(pos) {
-				passed = false
-				break
-			}
-		}
-
-	}
-
-	if pos != nil {
-		w.pos[objID] = pos
+		Rand:    rand.New(rand.NewSource(time.Now().UTC().Unix())),
+		Audio:   player,
+		Physics: physics.DefaultConfig(),
+		W:       width,
+		H:       height,
+		vp:      screen,
+		level:   level,
+
+		entities: make(map[string]*Entity),
+		grid:     spatial.NewGrid(gridCell),
 	}
-
-	return pos
 }
 
-// IncScore increments player's score.
-func (w *World) IncScore() {
+// Seed resets randomizer with the given "seed", so that a level can be reproduced.
+func (w *World) Seed(seed int64) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	w.score++
+	w.Rand = rand.New(rand.NewSource(seed))
 }
 
 // GetScore returns player's score.
-func (w *World) GetScore() int8 {
+func (w *World) GetScore() int {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 	return w.score
 }
 
-// IncLevel increments level number.
+// IncLevel increments level number and starts the score log of the next level.
 func (w *World) IncLevel() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	w.level++
+	w.scoreLog = nil
+	w.spotted = false
+}
+
+// NewGame starts a new game from the given "level" with no score.
+func (w *World) NewGame(level int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.level = level
+	w.score = 0
+	w.scoreLog = nil
+	w.combo, w.comboAt = 0, 0
+	w.spotted = false
 }
 
 // GetLevel returns level number.
-func (w *World) GetLevel() int8 {
+func (w *World) GetLevel() int {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 	return w.level
 }
 
-// Update ...
+// Update ends the current tick, noises and impacts made during it fade away and alert decays.
 func (w *World) Update() {
-	// noop
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.tick++
+	w.noises = w.noises[:0]
+	w.impacts = w.impacts[:0]
+	w.decayAlert()
 }